@@ -0,0 +1,132 @@
+// Package antivirustest provides a fake clamd for testing the code that scans files for malware.
+//
+// The fake listens on a local TCP port, decodes the chunks of the INSTREAM command, and
+// answers with the reply chosen by the test, such as "stream: OK" or
+// "stream: Win.Test.EICAR_HDB-1 FOUND".
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package antivirustest
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clamd is a fake clamd that answers INSTREAM commands.
+type Clamd struct {
+	// Address is the address of the fake, such as "tcp://127.0.0.1:3310".
+	Address string
+
+	listener  net.Listener
+	reply     func(content []byte) string
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	scanned [][]byte
+}
+
+// NewClamd starts a fake clamd on a random local port that answers every scan with the reply
+// returned for the streamed content. The fake is closed when the test ends.
+func NewClamd(t testing.TB, reply func(content []byte) string) *Clamd {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	clamd := &Clamd{
+		Address:  "tcp://" + listener.Addr().String(),
+		listener: listener,
+		reply:    reply,
+		done:     make(chan struct{}),
+	}
+	go func() {
+		defer close(clamd.done)
+		clamd.serve(t)
+	}()
+	t.Cleanup(clamd.Close)
+	return clamd
+}
+
+// Close stops the fake and waits for the scans in progress to end. Later scans fail to connect.
+func (c *Clamd) Close() {
+	c.closeOnce.Do(func() {
+		_ = c.listener.Close()
+	})
+	<-c.done
+}
+
+// Scanned returns the contents streamed to the fake, oldest first.
+func (c *Clamd) Scanned() [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]byte(nil), c.scanned...)
+}
+
+// serve answers the connections until the listener is closed.
+func (c *Clamd) serve(t testing.TB) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := c.listener.Accept()
+		if err != nil {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			if err := c.handle(conn); err != nil {
+				t.Errorf("fake clamd: %v", err)
+			}
+		}()
+	}
+}
+
+// handle reads an INSTREAM command and its chunks from the connection and writes the reply.
+func (c *Clamd) handle(conn net.Conn) error {
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+	r := bufio.NewReader(conn)
+
+	// Read the command, terminated by a NUL character as the "z" prefix requires.
+	command, err := r.ReadString(0)
+	if err != nil {
+		return fmt.Errorf("failed to read command: %w", err)
+	}
+	if command != "zINSTREAM\x00" {
+		return fmt.Errorf("unexpected command %q", command)
+	}
+
+	// Read the chunks, each prefixed by its length, up to the empty chunk ending the content.
+	var content []byte
+	for {
+		var length uint32
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			return fmt.Errorf("failed to read chunk length: %w", err)
+		}
+		if length == 0 {
+			break
+		}
+		chunk := make([]byte, length)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return fmt.Errorf("failed to read chunk: %w", err)
+		}
+		content = append(content, chunk...)
+	}
+
+	c.mu.Lock()
+	c.scanned = append(c.scanned, content)
+	c.mu.Unlock()
+
+	_, err = conn.Write([]byte(c.reply(content) + "\x00"))
+	return err
+}
@@ -0,0 +1,152 @@
+// Package antivirus scans the files submitted to the API Contact Form application for malware.
+//
+// This file implements Scanner with the INSTREAM command of clamd, the ClamAV daemon, over
+// TCP or a Unix socket. The content is streamed in chunks, so that it is never written to
+// the disk of the scanning host.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package antivirus
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize is the length of the chunks the content is streamed to clamd in.
+const clamdChunkSize = 64 << 10
+
+// ClamdConfig configures a Scanner that uses clamd.
+type ClamdConfig struct {
+	// Address locates clamd, such as "tcp://clamav:3310" or "unix:///run/clamav/clamd.sock".
+	// An address without a scheme, such as "clamav:3310", is a TCP address.
+	Address string
+	// Timeout bounds each scan, from connecting to reading the verdict.
+	Timeout time.Duration
+}
+
+// clamdScanner is the clamd implementation of Scanner.
+type clamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamdScanner creates a Scanner that streams content to clamd with the INSTREAM command.
+// It returns an error if the address is invalid. No connection is made until the first scan.
+func NewClamdScanner(config ClamdConfig) (Scanner, error) {
+	network, address := "tcp", config.Address
+	switch {
+	case strings.HasPrefix(address, "tcp://"):
+		address = strings.TrimPrefix(address, "tcp://")
+	case strings.HasPrefix(address, "unix://"):
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+	case strings.Contains(address, "://"):
+		return nil, fmt.Errorf("unsupported clamd address %q", config.Address)
+	}
+	if address == "" {
+		return nil, errors.New("clamd address is required")
+	}
+	if network == "tcp" {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, fmt.Errorf("invalid clamd address %q: %w", config.Address, err)
+		}
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = time.Minute
+	}
+	return &clamdScanner{network: network, address: address, timeout: config.Timeout}, nil
+}
+
+// Scan streams the content read from r to clamd and returns its verdict.
+func (s *clamdScanner) Scan(ctx context.Context, r io.Reader) (*Verdict, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	// Interrupt the exchange once the context is done.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	// Stream the content. When clamd refuses it, such as when it exceeds StreamMaxLength,
+	// clamd replies with an error and closes the connection, so the reply is read anyway.
+	readErr, writeErr := s.stream(conn, r)
+	if readErr != nil {
+		return nil, readErr
+	}
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && (reply == "" || !errors.Is(err, io.EOF)) {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("clamd did not reply in time: %w", ctx.Err())
+		}
+		if writeErr != nil {
+			return nil, fmt.Errorf("failed to send content to clamd: %w", writeErr)
+		}
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamdReply(reply)
+}
+
+// stream sends the INSTREAM command followed by the content read from r, in chunks prefixed
+// by their length, and the empty chunk that ends the content. It returns the error reading r,
+// if any, separately from the error writing to clamd.
+func (s *clamdScanner) stream(conn net.Conn, r io.Reader) (readErr error, writeErr error) {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, err
+	}
+
+	chunk := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := r.Read(chunk[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(chunk, uint32(n))
+			if _, err := conn.Write(chunk[:4+n]); err != nil {
+				return nil, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err, nil
+		}
+	}
+
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return nil, err
+}
+
+// parseClamdReply converts the reply of clamd, such as "stream: OK" or
+// "stream: Win.Test.EICAR_HDB-1 FOUND", to a verdict.
+func parseClamdReply(reply string) (*Verdict, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return &Verdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &Verdict{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	case strings.HasSuffix(result, " ERROR"):
+		return nil, fmt.Errorf("%w: %s", ErrScanFailed, strings.TrimSuffix(result, " ERROR"))
+	default:
+		return nil, fmt.Errorf("%w: unexpected clamd reply %q", ErrScanFailed, reply)
+	}
+}
@@ -0,0 +1,111 @@
+// Package antivirus_test tests the malware scanning of the API Contact Form application.
+//
+// This file tests the clamd Scanner against a fake clamd that decodes the INSTREAM chunks
+// and answers with the replies of a clean, an infected, and an unscannable file.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package antivirus_test
+
+import (
+	"api-contact-form/antivirus"
+	"api-contact-form/antivirus/antivirustest"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestClamdScannerScan checks the verdict of a scan for each kind of clamd reply, and that
+// the content reaches clamd intact, including content longer than a chunk.
+func TestClamdScannerScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		reply   string
+		want    *antivirus.Verdict
+		wantErr error
+	}{
+		{
+			name:    "clean",
+			content: []byte("hello, world"),
+			reply:   "stream: OK",
+			want:    &antivirus.Verdict{},
+		},
+		{
+			name:    "infected",
+			content: []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`),
+			reply:   "stream: Win.Test.EICAR_HDB-1 FOUND",
+			want:    &antivirus.Verdict{Infected: true, Signature: "Win.Test.EICAR_HDB-1"},
+		},
+		{
+			name:    "several chunks",
+			content: bytes.Repeat([]byte("0123456789abcdef"), 10<<10),
+			reply:   "stream: OK",
+			want:    &antivirus.Verdict{},
+		},
+		{
+			name:    "empty",
+			content: []byte{},
+			reply:   "stream: OK",
+			want:    &antivirus.Verdict{},
+		},
+		{
+			name:    "error",
+			content: []byte("too large"),
+			reply:   "INSTREAM size limit exceeded. ERROR",
+			wantErr: antivirus.ErrScanFailed,
+		},
+		{
+			name:    "unexpected reply",
+			content: []byte("hello, world"),
+			reply:   "stream: PONG",
+			wantErr: antivirus.ErrScanFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clamd := antivirustest.NewClamd(t, func([]byte) string { return tt.reply })
+			scanner, err := antivirus.NewClamdScanner(antivirus.ClamdConfig{Address: clamd.Address, Timeout: 5 * time.Second})
+			if err != nil {
+				t.Fatalf("NewClamdScanner() error = %v", err)
+			}
+
+			verdict, err := scanner.Scan(context.Background(), bytes.NewReader(tt.content))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Scan() error = %v, want %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Scan() error = %v", err)
+				}
+				if *verdict != *tt.want {
+					t.Errorf("Scan() verdict = %+v, want %+v", *verdict, *tt.want)
+				}
+			}
+
+			scanned := clamd.Scanned()
+			if len(scanned) != 1 || !bytes.Equal(scanned[0], tt.content) {
+				t.Errorf("clamd received %d scans, want the content once", len(scanned))
+			}
+		})
+	}
+}
+
+// TestClamdScannerUnreachable checks that a scan fails when clamd cannot be reached, without
+// reporting it as a failure of the scanning engine.
+func TestClamdScannerUnreachable(t *testing.T) {
+	clamd := antivirustest.NewClamd(t, func([]byte) string { return "stream: OK" })
+	scanner, err := antivirus.NewClamdScanner(antivirus.ClamdConfig{Address: clamd.Address, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClamdScanner() error = %v", err)
+	}
+	clamd.Close()
+
+	if _, err := scanner.Scan(context.Background(), bytes.NewReader([]byte("hello, world"))); err == nil || errors.Is(err, antivirus.ErrScanFailed) {
+		t.Errorf("Scan() error = %v, want a connection error", err)
+	}
+}
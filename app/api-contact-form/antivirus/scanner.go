@@ -0,0 +1,33 @@
+// Package antivirus scans the files submitted to the API Contact Form application for malware.
+//
+// It defines the Scanner interface and the Verdict of a scan, so that the rest of the
+// application does not depend on the scanning engine.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package antivirus
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrScanFailed indicates that the scanning engine could not scan a file, for example because
+// the file is larger than it accepts.
+var ErrScanFailed = errors.New("scan failed")
+
+// Verdict is the result of scanning a file.
+type Verdict struct {
+	// Infected reports whether malware was found in the file.
+	Infected bool
+	// Signature names the malware found, such as "Win.Test.EICAR_HDB-1". It is empty for clean files.
+	Signature string
+}
+
+// Scanner scans content for malware.
+type Scanner interface {
+	// Scan reads the content from r and returns the verdict of the scan. Errors reading r are
+	// returned as is; errors of the scanning engine are returned otherwise.
+	Scan(ctx context.Context, r io.Reader) (*Verdict, error)
+}
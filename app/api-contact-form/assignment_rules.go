@@ -0,0 +1,31 @@
+// Package main serves as the entry point for the API Contact Form application.
+//
+// This file builds the assignment rules applied to new contacts from the environment.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package main
+
+import (
+	"api-contact-form/config"
+	"api-contact-form/helpers"
+	"api-contact-form/repositories"
+	"api-contact-form/services"
+)
+
+// assignmentRules returns the assignment rules configured by the environment:
+//   - ASSIGNMENT_DEFAULT_QUEUE routes new contacts to the given queue.
+//   - ASSIGNMENT_ROUND_ROBIN lists, comma-separated, the team members to whom the new contacts
+//     of the default queue are assigned in turn. It requires ASSIGNMENT_DEFAULT_QUEUE.
+func assignmentRules(repository repositories.ContactRepository) []services.AssignmentRule {
+	queue := config.GetEnv("ASSIGNMENT_DEFAULT_QUEUE", "")
+	if queue == "" {
+		return nil
+	}
+
+	rules := []services.AssignmentRule{services.NewQueueRule(queue)}
+	if members := helpers.ParseEnvList("ASSIGNMENT_ROUND_ROBIN"); len(members) > 0 {
+		rules = append(rules, services.NewRoundRobinRule(repository, queue, members))
+	}
+	return rules
+}
@@ -0,0 +1,91 @@
+// Package main serves as the entry point for the API Contact Form application.
+//
+// This file builds the limits and the malware scanning of the files attached to contacts from
+// the environment.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package main
+
+import (
+	"api-contact-form/antivirus"
+	"api-contact-form/config"
+	"api-contact-form/helpers"
+	"api-contact-form/repositories"
+	"api-contact-form/services"
+	"api-contact-form/storage"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultAllowedAttachmentTypes lists the media types of the files that can be attached to
+// contacts unless ATTACHMENT_ALLOWED_TYPES is set.
+var defaultAllowedAttachmentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+// newAttachments returns the attachments of contacts, kept in the file store, with the limits
+// configured by the environment:
+//   - ATTACHMENT_MAX_FILES is the maximum number of files per submission. It defaults to 5.
+//   - ATTACHMENT_MAX_FILE_SIZE is the maximum size of each file, in bytes. It defaults to 10 MiB.
+//   - ATTACHMENT_ALLOWED_TYPES lists, comma-separated, the media types files may have.
+func newAttachments(files storage.FileStore, db *gorm.DB, queryTimeout time.Duration) *services.Attachments {
+	limits := services.AttachmentLimits{
+		MaxFiles:     config.GetEnvInt("ATTACHMENT_MAX_FILES", 5),
+		MaxFileSize:  int64(config.GetEnvInt("ATTACHMENT_MAX_FILE_SIZE", 10<<20)),
+		AllowedTypes: helpers.ParseEnvList("ATTACHMENT_ALLOWED_TYPES"),
+	}
+	if len(limits.AllowedTypes) == 0 {
+		limits.AllowedTypes = defaultAllowedAttachmentTypes
+	}
+	if limits.MaxFiles < 0 || limits.MaxFileSize <= 0 {
+		log.Fatal("ATTACHMENT_MAX_FILES and ATTACHMENT_MAX_FILE_SIZE must be positive")
+	}
+
+	return services.NewAttachments(files, repositories.NewContactAttachmentRepository(db, queryTimeout), newMalwareScanning(), limits)
+}
+
+// newMalwareScanning returns the malware scanning of attachments configured by the environment.
+// Scanning is disabled unless CLAMD_ADDRESS is set:
+//   - CLAMD_ADDRESS locates clamd, such as "tcp://clamav:3310" or "unix:///run/clamav/clamd.sock".
+//   - CLAMD_TIMEOUT bounds the scan of each file. It defaults to 1m.
+//   - ATTACHMENT_SCAN_POLICY is fail-closed (the default), to refuse the files that cannot be
+//     scanned, or fail-open, to store them marked as failed.
+func newMalwareScanning() services.MalwareScanning {
+	address := config.GetEnv("CLAMD_ADDRESS", "")
+	if address == "" {
+		log.Println("CLAMD_ADDRESS is not set; attachments are not scanned for malware")
+		return services.MalwareScanning{}
+	}
+
+	scanner, err := antivirus.NewClamdScanner(antivirus.ClamdConfig{
+		Address: address,
+		Timeout: config.GetEnvDuration("CLAMD_TIMEOUT", time.Minute),
+	})
+	if err != nil {
+		log.Fatalf("Invalid clamd configuration: %v", err)
+	}
+
+	scanning := services.MalwareScanning{Scanner: scanner}
+	switch policy := config.GetEnv("ATTACHMENT_SCAN_POLICY", "fail-closed"); policy {
+	case "fail-closed":
+	case "fail-open":
+		scanning.FailOpen = true
+	default:
+		log.Fatalf("Unsupported ATTACHMENT_SCAN_POLICY %q", policy)
+	}
+	return scanning
+}
+
+// maxContactBodyBytes returns the maximum size of a request creating a contact: room for the
+// largest allowed files plus the other fields of the form.
+func maxContactBodyBytes(limits services.AttachmentLimits) int64 {
+	return int64(limits.MaxFiles)*limits.MaxFileSize + 1<<20
+}
@@ -1,7 +1,8 @@
 // Package config handles the initialization and configuration of the database connection.
 //
-// It establishes a connection to a MySQL database using GORM, configures the connection pool,
-// and performs automatic migrations for the Contact model.
+// It establishes a connection to a MySQL/MariaDB, PostgreSQL, or SQLite database using GORM,
+// retrying with exponential backoff while the database is not ready yet, and configures the
+// connection pool. Schema changes are managed by the migrations package.
 //
 // Author: Tri Wicaksono
 // Website: https://triwicaksono.com
@@ -9,11 +10,13 @@ package config
 
 import (
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
-	"api-contact-form/models"
-
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
@@ -23,36 +26,25 @@ import (
 var DB *gorm.DB
 
 // InitDB initializes the database connection using environment variables.
-// It sets up the connection pool and performs automatic migrations for the Contact model.
+// It sets up the connection pool. The schema is not modified; see the migrations package.
 //
 // The function performs the following steps:
-// 1. Retrieves database configuration from environment variables.
-// 2. Constructs the Data Source Name (DSN) for MySQL connection.
-// 3. Opens the database connection using GORM with a singular table naming strategy.
-// 4. Configures the connection pool with specified limits.
-// 5. Automatically migrates the Contact model to create or update the corresponding table.
+//  1. Selects the database driver from the DB_DRIVER environment variable.
+//  2. Constructs the Data Source Name (DSN) for the selected driver.
+//  3. Opens the database connection using GORM with a singular table naming strategy,
+//     retrying with exponential backoff until DB_CONNECT_TIMEOUT elapses.
+//  4. Configures the connection pool with the limits from the environment.
 //
 // If any step fails, the function will panic with an appropriate error message.
 func InitDB() {
-	// Retrieve database configuration from environment variables with default values.
-	dbUser := GetEnv("DB_USER", "user")
-	dbPassword := GetEnv("DB_PASSWORD", "password")
-	dbHost := GetEnv("DB_HOST", "db")
-	dbPort := GetEnv("DB_PORT", "3306")
-	dbName := GetEnv("DB_NAME", "contactsdb")
-
-	// Construct the Data Source Name (DSN) for MySQL connection.
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
-
-	var err error
-
-	// Open the database connection using GORM with a singular table naming strategy.
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
-		NamingStrategy: schema.NamingStrategy{
-			SingularTable: true,
-		},
-	})
+	// Select the GORM dialector for the configured database driver.
+	dialector, err := openDialector(GetEnv("DB_DRIVER", "mysql"))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	// Open the database connection, waiting for the database to become ready.
+	DB, err = openWithRetry(dialector)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to connect to database: %v", err))
 	}
@@ -63,12 +55,113 @@ func InitDB() {
 		panic("Failed to get database instance!")
 	}
 
-	sqlDB.SetMaxOpenConns(10)           // Maximum number of open connections to the database.
-	sqlDB.SetMaxIdleConns(5)            // Maximum number of idle connections in the pool.
-	sqlDB.SetConnMaxLifetime(time.Hour) // Maximum amount of time a connection may be reused.
+	sqlDB.SetMaxOpenConns(GetEnvInt("DB_MAX_OPEN_CONNS", 10))                   // Maximum number of open connections to the database.
+	sqlDB.SetMaxIdleConns(GetEnvInt("DB_MAX_IDLE_CONNS", 5))                    // Maximum number of idle connections in the pool.
+	sqlDB.SetConnMaxLifetime(GetEnvDuration("DB_CONN_MAX_LIFETIME", time.Hour)) // Maximum amount of time a connection may be reused.
+	sqlDB.SetConnMaxIdleTime(GetEnvDuration("DB_CONN_MAX_IDLE_TIME", 0))        // Maximum amount of time a connection may be idle; zero means no limit.
+}
+
+// minConnectRetryInterval is the shortest wait between connection attempts, so that a zero or
+// negative retry interval cannot make the attempts hammer the database in a hot loop.
+const minConnectRetryInterval = 100 * time.Millisecond
 
-	// Automatically migrate the Contact model to create or update the corresponding table.
-	if err := DB.AutoMigrate(&models.Contact{}); err != nil {
-		panic(fmt.Sprintf("AutoMigrate failed: %v", err))
+// openWithRetry opens the database connection using GORM with a singular table naming strategy.
+// While the database is unreachable, it retries with exponential backoff, starting at
+// DB_CONNECT_RETRY_INTERVAL and doubling up to DB_CONNECT_RETRY_MAX_INTERVAL, until
+// DB_CONNECT_TIMEOUT has elapsed. Both intervals are raised to at least
+// minConnectRetryInterval, and the maximum interval to at least the first one.
+//
+// It returns the connected GORM DB, or the last connection error once the deadline is reached.
+func openWithRetry(dialector gorm.Dialector) (*gorm.DB, error) {
+	deadline := time.Now().Add(GetEnvDuration("DB_CONNECT_TIMEOUT", time.Minute))
+	interval := max(GetEnvDuration("DB_CONNECT_RETRY_INTERVAL", time.Second), minConnectRetryInterval)
+	maxInterval := max(GetEnvDuration("DB_CONNECT_RETRY_MAX_INTERVAL", 15*time.Second), interval)
+
+	for attempt := 1; ; attempt++ {
+		db, err := gorm.Open(dialector, &gorm.Config{
+			NamingStrategy: schema.NamingStrategy{
+				SingularTable: true,
+			},
+			// Translate driver-specific errors, such as duplicate keys, into GORM errors.
+			TranslateError: true,
+		})
+		if err == nil {
+			return db, nil
+		}
+
+		// Release the pool of the failed attempt before trying again.
+		if db != nil {
+			if sqlDB, dbErr := db.DB(); dbErr == nil {
+				sqlDB.Close()
+			}
+		}
+
+		// Give up once the deadline has passed; the last wait never overshoots it.
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, err
+		}
+		wait := min(interval, remaining)
+		log.Printf("Database is not ready (attempt %d): %v. Retrying in %s", attempt, err, wait.Round(time.Millisecond))
+		time.Sleep(wait)
+		interval = min(interval*2, maxInterval)
 	}
 }
+
+// openDialector returns the GORM dialector for the given database driver name.
+// Supported drivers are "mysql" (alias "mariadb"), "postgres" (alias "postgresql"),
+// and "sqlite" (alias "sqlite3"). The DB_DSN environment variable, when set,
+// overrides the DSN built from the individual settings.
+//
+// It returns an error if the driver is not supported.
+func openDialector(driver string) (gorm.Dialector, error) {
+	dsn := GetEnv("DB_DSN", "")
+
+	switch strings.ToLower(driver) {
+	case "mysql", "mariadb":
+		if dsn == "" {
+			dsn = mysqlDSN()
+		}
+		return mysql.Open(dsn), nil
+	case "postgres", "postgresql":
+		if dsn == "" {
+			dsn = postgresDSN()
+		}
+		return postgres.Open(dsn), nil
+	case "sqlite", "sqlite3":
+		if dsn == "" {
+			dsn = sqliteDSN()
+		}
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+}
+
+// mysqlDSN constructs the Data Source Name for a MySQL/MariaDB connection.
+func mysqlDSN() string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		GetEnv("DB_USER", "user"),
+		GetEnv("DB_PASSWORD", "password"),
+		GetEnv("DB_HOST", "db"),
+		GetEnv("DB_PORT", "3306"),
+		GetEnv("DB_NAME", "contactsdb"))
+}
+
+// postgresDSN constructs the Data Source Name for a PostgreSQL connection.
+func postgresDSN() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		GetEnv("DB_HOST", "db"),
+		GetEnv("DB_PORT", "5432"),
+		GetEnv("DB_USER", "user"),
+		GetEnv("DB_PASSWORD", "password"),
+		GetEnv("DB_NAME", "contactsdb"),
+		GetEnv("DB_SSLMODE", "disable"))
+}
+
+// sqliteDSN constructs the Data Source Name for a SQLite database file.
+// Foreign keys are enforced and writers wait for locks instead of failing immediately.
+func sqliteDSN() string {
+	return GetEnv("DB_SQLITE_PATH", "contactsdb.sqlite") +
+		"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
@@ -1,13 +1,18 @@
 // Package config provides utilities for managing configuration settings.
 //
 // It includes functions to retrieve environment variables with default fallback values,
-// ensuring that the application can gracefully handle missing or unset environment variables.
+// ensuring that the application can gracefully handle missing, unset, or malformed environment variables.
 //
 // Author: Tri Wicaksono
 // Website: https://triwicaksono.com
 package config
 
-import "os"
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
 
 // GetEnv retrieves the value of the environment variable named by the key.
 // If the environment variable is not set or is empty, it returns the provided default value.
@@ -25,3 +30,47 @@ func GetEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+// GetEnvInt retrieves an integer environment variable.
+// If the environment variable is not set, is empty, or cannot be parsed, it returns the provided default value.
+//
+// Parameters:
+//   - key: The name of the environment variable to retrieve.
+//   - defaultVal: The default value to return if the variable is not set or invalid.
+//
+// Returns:
+//   - An integer containing the value of the environment variable or the default value.
+func GetEnvInt(key string, defaultVal int) int {
+	value := GetEnv(key, "")
+	if value == "" {
+		return defaultVal
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: Could not parse integer value for %s: %v. Using default: %v", key, err, defaultVal)
+		return defaultVal
+	}
+	return parsed
+}
+
+// GetEnvDuration retrieves a duration environment variable, such as "30s" or "1h".
+// If the environment variable is not set, is empty, or cannot be parsed, it returns the provided default value.
+//
+// Parameters:
+//   - key: The name of the environment variable to retrieve.
+//   - defaultVal: The default value to return if the variable is not set or invalid.
+//
+// Returns:
+//   - A time.Duration containing the value of the environment variable or the default value.
+func GetEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	value := GetEnv(key, "")
+	if value == "" {
+		return defaultVal
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: Could not parse duration value for %s: %v. Using default: %v", key, err, defaultVal)
+		return defaultVal
+	}
+	return parsed
+}
@@ -0,0 +1,67 @@
+// Package main serves as the entry point for the API Contact Form application.
+//
+// This file builds the file store, which keeps the files stored by the API, from the environment.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package main
+
+import (
+	"api-contact-form/config"
+	"api-contact-form/helpers"
+	"api-contact-form/storage"
+	"context"
+	"log"
+	"time"
+)
+
+// newFileStore returns the file store selected by STORAGE_DRIVER. The local driver, the
+// default, keeps files on disk in STORAGE_LOCAL_PATH, which defaults to "uploads". The s3 driver
+// keeps files in a bucket of S3-compatible object storage, such as Amazon S3 or MinIO:
+//   - STORAGE_S3_ENDPOINT is the URL of the service. It defaults to "https://s3.amazonaws.com".
+//   - STORAGE_S3_PUBLIC_ENDPOINT is the URL clients reach the service at, if not the same.
+//   - STORAGE_S3_REGION is the region of the bucket. It defaults to "us-east-1".
+//   - STORAGE_S3_BUCKET is the name of the bucket. It is required.
+//   - STORAGE_S3_ACCESS_KEY and STORAGE_S3_SECRET_KEY are the credentials. When empty, the
+//     AWS_* environment variables or the instance role are used.
+//   - STORAGE_S3_PATH_STYLE addresses the bucket in the path of URLs, as MinIO expects.
+//   - STORAGE_S3_PREFIX is prepended to every key, such as "production/".
+//   - STORAGE_S3_SSE is the server-side encryption of new objects: AES256 or aws:kms, with
+//     the key STORAGE_S3_SSE_KMS_KEY_ID. It is empty by default.
+//   - STORAGE_S3_PRESIGN_EXPIRY is how long download URLs stay valid. It defaults to 15m;
+//     0 streams downloads through the API instead.
+//   - STORAGE_S3_CREATE_BUCKET creates the bucket when it does not exist.
+func newFileStore() storage.FileStore {
+	switch driver := config.GetEnv("STORAGE_DRIVER", "local"); driver {
+	case "local":
+		files, err := storage.NewLocalStore(config.GetEnv("STORAGE_LOCAL_PATH", "uploads"))
+		if err != nil {
+			log.Fatalf("Failed to open the file store: %v", err)
+		}
+		return files
+	case "s3":
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		files, err := storage.NewS3Store(ctx, storage.S3Config{
+			Endpoint:       config.GetEnv("STORAGE_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			PublicEndpoint: config.GetEnv("STORAGE_S3_PUBLIC_ENDPOINT", ""),
+			Region:         config.GetEnv("STORAGE_S3_REGION", "us-east-1"),
+			Bucket:         config.GetEnv("STORAGE_S3_BUCKET", ""),
+			AccessKey:      config.GetEnv("STORAGE_S3_ACCESS_KEY", ""),
+			SecretKey:      config.GetEnv("STORAGE_S3_SECRET_KEY", ""),
+			PathStyle:      helpers.GetEnvBool("STORAGE_S3_PATH_STYLE", false),
+			Prefix:         config.GetEnv("STORAGE_S3_PREFIX", ""),
+			SSE:            config.GetEnv("STORAGE_S3_SSE", storage.SSENone),
+			SSEKMSKeyID:    config.GetEnv("STORAGE_S3_SSE_KMS_KEY_ID", ""),
+			PresignExpiry:  config.GetEnvDuration("STORAGE_S3_PRESIGN_EXPIRY", 15*time.Minute),
+			CreateBucket:   helpers.GetEnvBool("STORAGE_S3_CREATE_BUCKET", false),
+		})
+		if err != nil {
+			log.Fatalf("Failed to open the S3 file store: %v", err)
+		}
+		return files
+	default:
+		log.Fatalf("Unsupported STORAGE_DRIVER %q", driver)
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// It defines the ContactAttachmentHandler struct, which provides the download of the files
+// attached to contacts, and the helper that collects the files of a multipart form.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/services"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// attachmentsFormField is the multipart form field holding the files attached to a contact.
+const attachmentsFormField = "attachments"
+
+// ContactAttachmentHandler handles HTTP requests related to the files attached to contacts.
+type ContactAttachmentHandler struct {
+	service services.ContactAttachmentService
+}
+
+// NewContactAttachmentHandler creates a new instance of ContactAttachmentHandler with the provided ContactAttachmentService.
+func NewContactAttachmentHandler(service services.ContactAttachmentService) *ContactAttachmentHandler {
+	return &ContactAttachmentHandler{service}
+}
+
+// DownloadAttachment responds with the content of a file attached to a contact.
+//
+// It expects the contact ID and the attachment ID as URL parameters.
+// When the file store provides temporary download URLs, it redirects to one with a 302 status
+// code. Otherwise it streams the file with a 200 status code, the content type detected on
+// upload, and a Content-Disposition header that makes browsers download it rather than display it.
+func (h *ContactAttachmentHandler) DownloadAttachment(c *gin.Context) {
+	// Retrieve the 'id' and 'attachment_id' parameters from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+	id, ok := attachmentIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to retrieve the attachment.
+	download, err := h.service.DownloadAttachment(c.Request.Context(), contactID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Redirect to the temporary URL of the file, which must not outlive it in caches.
+	if download.URL != "" {
+		c.Header("Cache-Control", "no-store")
+		c.Redirect(http.StatusFound, download.URL)
+		return
+	}
+
+	// Stream the file to the client.
+	defer download.Content.Close()
+	attachment := download.Attachment
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, download.Content, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// formUploads converts the files of a multipart form field into uploads for the service layer.
+func formUploads(files []*multipart.FileHeader) []services.Upload {
+	uploads := make([]services.Upload, 0, len(files))
+	for _, file := range files {
+		uploads = append(uploads, services.Upload{
+			Filename: file.Filename,
+			Size:     file.Size,
+			Open: func() (io.ReadCloser, error) {
+				return file.Open()
+			},
+		})
+	}
+	return uploads
+}
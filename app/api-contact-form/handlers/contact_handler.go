@@ -8,15 +8,25 @@
 package handlers
 
 import (
+	"api-contact-form/helpers"
+	"api-contact-form/i18n"
 	"api-contact-form/requests"
 	"api-contact-form/responses"
 	"api-contact-form/services"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
+// maxActorLength is the maximum length of the X-Actor request header.
+const maxActorLength = 100
+
+// mimeMergePatch is the media type of JSON Merge Patch (RFC 7396) request bodies.
+const mimeMergePatch = "application/merge-patch+json"
+
 // ContactHandler handles HTTP requests related to contact operations.
 type ContactHandler struct {
 	service services.ContactService
@@ -29,69 +39,193 @@ func NewContactHandler(service services.ContactService) *ContactHandler {
 
 // CreateContact handles the creation of a new contact.
 //
-// It expects a JSON payload matching the ContactRequest structure.
-// Upon successful creation, it returns the created contact with a 201 status code.
+// It expects a JSON payload matching the ContactRequest structure, or a multipart form with
+// the same fields and up to a few files in the "attachments" field.
+// Upon successful creation, it returns the public view of the created contact with a 201 status code.
 // If there's an error in binding the request or creating the contact, it returns an appropriate error response.
 func (h *ContactHandler) CreateContact(c *gin.Context) {
 	var req requests.ContactRequest
+	var uploads []services.Upload
 
-	// Bind the JSON payload to the ContactRequest struct.
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, responses.APIResponse{
-			Code:    "BAD_REQUEST",
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if c.ContentType() == binding.MIMEMultipartPOSTForm {
+		// Bind the multipart form to the ContactRequest struct and collect the attached files.
+		if err := c.ShouldBindWith(&req, binding.FormMultipart); err != nil {
+			respondFormError(c, err)
+			return
+		}
+		uploads = formUploads(c.Request.MultipartForm.File[attachmentsFormField])
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		// Bind the JSON payload to the ContactRequest struct.
+		respondBindingError(c, err)
 		return
 	}
 
 	// Use the service layer to create a new contact.
-	contact, err := h.service.CreateContact(&req)
+	contact, err := h.service.CreateContact(c.Request.Context(), &req, uploads...)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, responses.APIResponse{
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: err.Error(),
-			Data:    nil,
-		})
+		respondError(c, err)
 		return
 	}
 
-	// Respond with the created contact and a success message.
+	// Respond with the created contact, its entity tag, and a success message.
+	setContactETag(c, contact)
 	c.JSON(http.StatusCreated, responses.APIResponse{
 		Code:    "CREATED",
-		Message: "Contact created successfully",
-		Data:    responses.ContactResponseFromModel(contact),
+		Message: translate(c, i18n.MsgContactCreated),
+		Data:    responses.PublicContactResponseFromModel(contact),
 	})
 }
 
-// GetContacts retrieves all contacts.
+// GetContacts retrieves a paginated list of contacts.
 //
-// It interacts with the service layer to fetch all contact records.
-// On success, it returns the list of contacts with a 200 status code.
-// In case of an error, it responds with a 500 status code and an error message.
+// It accepts paging (page, per_page), filtering (name, email, status, assignee, queue,
+// tag, tag_match, created_from, created_to), and sorting (sort) query parameters matching
+// the ContactListQuery structure.
+// When pagination=cursor or a cursor is supplied, keyset pagination is used instead.
+// On success, it returns the page of contacts and pagination metadata with a 200 status code.
+// If the query parameters are invalid, it responds with a 400 status code.
+// Other errors are mapped to responses by respondError.
 func (h *ContactHandler) GetContacts(c *gin.Context) {
-	// Fetch all contacts using the service layer.
-	contacts, err := h.service.GetAllContacts()
+	var query requests.ContactListQuery
+
+	// Bind the query parameters to the ContactListQuery struct.
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	h.listContacts(c, &query)
+}
+
+// GetMyContacts retrieves a paginated list of the contacts assigned to the caller.
+//
+// The caller is identified by the X-Actor header, which is required. It accepts the same
+// query parameters as GetContacts, except that the assignee parameter is ignored.
+// If the header is missing or the query parameters are invalid, it responds with a 400 status code.
+func (h *ContactHandler) GetMyContacts(c *gin.Context) {
+	// Identify the caller.
+	actor, ok := requiredActorHeader(c)
+	if !ok {
+		return
+	}
+
+	// Bind the query parameters to the ContactListQuery struct.
+	var query requests.ContactListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	// Only list the contacts assigned to the caller.
+	query.Assignee = actor
+	h.listContacts(c, &query)
+}
+
+// listContacts responds with the page of contacts matching a bound list query, using
+// keyset pagination when the query asks for it.
+// Errors are mapped to responses by respondError.
+func (h *ContactHandler) listContacts(c *gin.Context, query *requests.ContactListQuery) {
+	// Use keyset pagination when requested.
+	if query.IsCursorMode() {
+		h.getContactsByCursor(c, query)
+		return
+	}
+
+	// Fetch the requested page of contacts using the service layer.
+	contacts, total, err := h.service.GetAllContacts(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, responses.APIResponse{
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: err.Error(),
-			Data:    nil,
-		})
+		respondError(c, err)
 		return
 	}
 
 	// Convert the contact models to response formats.
-	var contactResponses []responses.ContactResponse
+	contactResponses := make([]responses.ContactResponse, 0, len(contacts))
 	for _, contact := range contacts {
 		contactResponses = append(contactResponses, responses.ContactResponseFromModel(&contact))
 	}
 
-	// Respond with the list of contacts.
+	// Respond with the list of contacts and the pagination details.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactsRetrieved),
+		Data:    contactResponses,
+		Meta:    responses.NewPaginationMeta(query.Page, query.PerPage, total),
+	})
+}
+
+// getContactsByCursor responds with a page of contacts fetched using keyset pagination.
+//
+// If the cursor is malformed, it responds with a 400 status code.
+// Other errors are mapped to responses by respondError.
+func (h *ContactHandler) getContactsByCursor(c *gin.Context, query *requests.ContactListQuery) {
+	// Fetch the requested page of contacts using the service layer.
+	page, err := h.service.GetContactsByCursor(c.Request.Context(), query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the contact models to response formats.
+	contactResponses := make([]responses.ContactResponse, 0, len(page.Contacts))
+	for _, contact := range page.Contacts {
+		contactResponses = append(contactResponses, responses.ContactResponseFromModel(&contact))
+	}
+
+	// Encode the cursors of the adjacent pages.
+	var next, prev string
+	if page.Next != nil {
+		next = page.Next.Encode()
+	}
+	if page.Prev != nil {
+		prev = page.Prev.Encode()
+	}
+
+	// Respond with the list of contacts and the cursors.
 	c.JSON(http.StatusOK, responses.APIResponse{
 		Code:    "SUCCESS",
-		Message: "Contacts retrieved successfully",
+		Message: translate(c, i18n.MsgContactsRetrieved),
 		Data:    contactResponses,
+		Meta:    responses.NewCursorMeta(query.PerPage, next, prev),
+	})
+}
+
+// SearchContacts searches contacts by keyword.
+//
+// It accepts the query parameters matching the ContactSearchQuery structure: the search
+// query (q) and paging (page, per_page).
+// On success, it returns the matching contacts ranked by relevance, with highlighted
+// snippets and pagination metadata, with a 200 status code.
+// If the query parameters are invalid, it responds with a 400 status code.
+// Other errors are mapped to responses by respondError.
+func (h *ContactHandler) SearchContacts(c *gin.Context) {
+	var query requests.ContactSearchQuery
+
+	// Bind the query parameters to the ContactSearchQuery struct.
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	// Search contacts using the service layer.
+	results, total, err := h.service.SearchContacts(c.Request.Context(), &query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the search results to response formats.
+	terms := helpers.SearchTerms(query.Q)
+	searchResponses := make([]responses.ContactSearchResponse, 0, len(results))
+	for _, result := range results {
+		searchResponses = append(searchResponses, responses.ContactSearchResponseFromResult(&result, terms))
+	}
+
+	// Respond with the search results and the pagination details.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactsRetrieved),
+		Data:    searchResponses,
+		Meta:    responses.NewPaginationMeta(query.Page, query.PerPage, total),
 	})
 }
 
@@ -99,54 +233,48 @@ func (h *ContactHandler) GetContacts(c *gin.Context) {
 //
 // It expects the contact ID as a URL parameter.
 // If the ID is invalid or the contact does not exist, it returns an appropriate error response.
-// On success, it returns the contact details with a 200 status code.
+// On success, it returns the contact details with a 200 status code, and the contact's version
+// as its ETag.
 func (h *ContactHandler) GetContact(c *gin.Context) {
 	// Retrieve the 'id' parameter from the URL.
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.APIResponse{
-			Code:    "BAD_REQUEST",
-			Message: "Invalid ID",
-			Data:    nil,
-		})
+	id, ok := contactIDParam(c)
+	if !ok {
 		return
 	}
 
 	// Fetch the contact by ID using the service layer.
-	contact, err := h.service.GetContactByID(uint(id))
+	contact, err := h.service.GetContactByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, responses.APIResponse{
-			Code:    "NOT_FOUND",
-			Message: "Contact not found",
-			Data:    nil,
-		})
+		respondError(c, err)
 		return
 	}
 
-	// Respond with the contact details.
+	// Respond with the contact details, its entity tag, and a success message.
+	setContactETag(c, contact)
 	c.JSON(http.StatusOK, responses.APIResponse{
 		Code:    "SUCCESS",
-		Message: "Contact retrieved successfully",
+		Message: translate(c, i18n.MsgContactRetrieved),
 		Data:    responses.ContactResponseFromModel(contact),
 	})
 }
 
 // UpdateContact updates an existing contact by its ID.
 //
-// It expects the contact ID as a URL parameter and a JSON payload matching the ContactRequest structure.
-// If the ID is invalid or the contact does not exist, it returns an appropriate error response.
-// On successful update, it returns the updated contact with a 200 status code.
+// It expects the contact ID as a URL parameter, the contact's ETag in the If-Match header,
+// and a JSON payload matching the ContactRequest structure.
+// If the ID is invalid, the contact does not exist, or the contact was changed since the ETag
+// was read, it returns an appropriate error response.
+// On successful update, it returns the updated contact and its new ETag with a 200 status code.
 func (h *ContactHandler) UpdateContact(c *gin.Context) {
 	// Retrieve the 'id' parameter from the URL.
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.APIResponse{
-			Code:    "BAD_REQUEST",
-			Message: "Invalid ID",
-			Data:    nil,
-		})
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Retrieve the contact version expected by the If-Match header.
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
@@ -154,66 +282,543 @@ func (h *ContactHandler) UpdateContact(c *gin.Context) {
 
 	// Bind the JSON payload to the ContactRequest struct.
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, responses.APIResponse{
-			Code:    "BAD_REQUEST",
-			Message: err.Error(),
-			Data:    nil,
-		})
+		respondBindingError(c, err)
 		return
 	}
 
 	// Use the service layer to update the contact.
-	contact, err := h.service.UpdateContact(uint(id), &req)
+	contact, err := h.service.UpdateContact(c.Request.Context(), id, version, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, responses.APIResponse{
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: err.Error(),
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated contact, its new entity tag, and a success message.
+	setContactETag(c, contact)
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactUpdated),
+		Data:    responses.ContactResponseFromModel(contact),
+	})
+}
+
+// PatchContact partially updates an existing contact by its ID.
+//
+// It expects the contact ID as a URL parameter, the contact's ETag in the If-Match header,
+// and a JSON Merge Patch (RFC 7396) payload containing only the ContactRequest fields to change,
+// sent as application/merge-patch+json or application/json. Other media types, such as JSON
+// Patch, are refused with a 415 status code rather than misread as a merge patch.
+// Only the fields present in the patch are validated and updated.
+// If the ID is invalid, the payload is not a JSON object, the contact does not exist, or the
+// contact was changed since the ETag was read, it returns an appropriate error response.
+// On successful update, it returns the updated contact and its new ETag with a 200 status code.
+func (h *ContactHandler) PatchContact(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Only accept the media types of a JSON Merge Patch.
+	if contentType := c.ContentType(); contentType != mimeMergePatch && contentType != binding.MIMEJSON {
+		c.JSON(http.StatusUnsupportedMediaType, responses.APIResponse{
+			Code:    "UNSUPPORTED_MEDIA_TYPE",
+			Message: translate(c, i18n.MsgUnsupportedMediaType),
 			Data:    nil,
 		})
 		return
 	}
 
-	// Respond with the updated contact and a success message.
+	// Retrieve the contact version expected by the If-Match header.
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var patch map[string]interface{}
+
+	// Bind the JSON payload, which must be a JSON object, to the patch.
+	if err := c.ShouldBindJSON(&patch); err != nil || patch == nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to patch the contact.
+	contact, err := h.service.PatchContact(c.Request.Context(), id, version, patch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated contact, its new entity tag, and a success message.
+	setContactETag(c, contact)
 	c.JSON(http.StatusOK, responses.APIResponse{
 		Code:    "SUCCESS",
-		Message: "Contact updated successfully",
+		Message: translate(c, i18n.MsgContactUpdated),
 		Data:    responses.ContactResponseFromModel(contact),
 	})
 }
 
 // DeleteContact removes a contact by its ID.
 //
-// It expects the contact ID as a URL parameter.
-// If the ID is invalid or the contact does not exist, it returns an appropriate error response.
+// It expects the contact ID as a URL parameter. By default the contact is moved to the trash,
+// from which it can be restored. With the permanent=true query parameter, the contact is
+// purged permanently instead, whether it is in the trash or not. Either way, the contact's ETag
+// is expected in the If-Match header.
+// If the ID is invalid, the contact does not exist, or the contact was changed since the ETag
+// was read, it returns an appropriate error response.
 // On successful deletion, it returns a success message with a 200 status code.
 func (h *ContactHandler) DeleteContact(c *gin.Context) {
 	// Retrieve the 'id' parameter from the URL.
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Retrieve the 'permanent' query parameter.
+	permanent, err := strconv.ParseBool(c.DefaultQuery("permanent", "false"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, responses.APIResponse{
 			Code:    "BAD_REQUEST",
-			Message: "Invalid ID",
+			Message: translate(c, i18n.MsgInvalidPermanent),
 			Data:    nil,
 		})
 		return
 	}
 
-	// Use the service layer to delete the contact.
-	err = h.service.DeleteContact(uint(id))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, responses.APIResponse{
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: err.Error(),
+	// Retrieve the contact version expected by the If-Match header.
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to purge the contact when requested.
+	if permanent {
+		if err := h.service.PurgeContact(c.Request.Context(), id, version); err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, responses.APIResponse{
+			Code:    "SUCCESS",
+			Message: translate(c, i18n.MsgContactPurged),
 			Data:    nil,
 		})
 		return
 	}
 
+	// Use the service layer to move the contact to the trash.
+	err = h.service.DeleteContact(c.Request.Context(), id, version)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
 	// Respond with a success message.
 	c.JSON(http.StatusOK, responses.APIResponse{
 		Code:    "SUCCESS",
-		Message: "Contact deleted successfully",
+		Message: translate(c, i18n.MsgContactDeleted),
 		Data:    nil,
 	})
 }
+
+// GetTrashedContacts retrieves a paginated list of deleted contacts.
+//
+// It accepts the same paging, filtering, and sorting query parameters as GetContacts,
+// except that cursor pagination is not supported.
+// On success, it returns the page of deleted contacts and pagination metadata with a 200 status code.
+// If the query parameters are invalid, it responds with a 400 status code.
+// Other errors are mapped to responses by respondError.
+func (h *ContactHandler) GetTrashedContacts(c *gin.Context) {
+	var query requests.ContactListQuery
+
+	// Bind the query parameters to the ContactListQuery struct.
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	// Fetch the requested page of deleted contacts using the service layer.
+	contacts, total, err := h.service.GetTrashedContacts(c.Request.Context(), &query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the contact models to response formats.
+	contactResponses := make([]responses.ContactResponse, 0, len(contacts))
+	for _, contact := range contacts {
+		contactResponses = append(contactResponses, responses.ContactResponseFromModel(&contact))
+	}
+
+	// Respond with the list of deleted contacts and the pagination details.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgTrashRetrieved),
+		Data:    contactResponses,
+		Meta:    responses.NewPaginationMeta(query.Page, query.PerPage, total),
+	})
+}
+
+// RestoreContact moves a deleted contact out of the trash by its ID.
+//
+// It expects the contact ID as a URL parameter.
+// If the ID is invalid or the contact is not in the trash, it returns an appropriate error response.
+// On successful restoration, it returns the restored contact with a 200 status code.
+func (h *ContactHandler) RestoreContact(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to restore the contact.
+	contact, err := h.service.RestoreContact(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the restored contact, its entity tag, and a success message.
+	setContactETag(c, contact)
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactRestored),
+		Data:    responses.ContactResponseFromModel(contact),
+	})
+}
+
+// TransitionContact moves a contact to another status of the handling workflow by its ID.
+//
+// It expects the contact ID as a URL parameter and a JSON payload matching the
+// ContactTransitionRequest structure. The optional X-Actor header identifies who made the
+// change, and the optional If-Match header makes the change conditional on the contact version.
+// If the workflow does not allow the transition, it responds with a 409 status code and the
+// INVALID_STATUS_TRANSITION code.
+// On success, it returns the updated contact with a 200 status code.
+func (h *ContactHandler) TransitionContact(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Read the actor and the expected version, if any, from the headers.
+	actor, ok := actorHeader(c)
+	if !ok {
+		return
+	}
+	version, ok := optionalIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	// Bind the JSON payload to the ContactTransitionRequest struct.
+	var req requests.ContactTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to change the status of the contact.
+	contact, err := h.service.TransitionContact(c.Request.Context(), id, version, &req, actor)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated contact, its entity tag, and a success message.
+	setContactETag(c, contact)
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactTransitioned),
+		Data:    responses.ContactResponseFromModel(contact),
+	})
+}
+
+// GetContactTransitions retrieves the status history of a contact by its ID.
+//
+// It expects the contact ID as a URL parameter.
+// If the ID is invalid or the contact is not found, it returns an appropriate error response.
+// On success, it returns the status transitions, oldest first, with a 200 status code.
+func (h *ContactHandler) GetContactTransitions(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to retrieve the status history.
+	transitions, err := h.service.GetContactTransitions(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the transitions to response format.
+	transitionResponses := make([]responses.ContactStatusTransitionResponse, 0, len(transitions))
+	for _, transition := range transitions {
+		transitionResponses = append(transitionResponses, responses.ContactStatusTransitionResponseFromModel(&transition))
+	}
+
+	// Respond with the status history and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgTransitionsRetrieved),
+		Data:    transitionResponses,
+	})
+}
+
+// AssignContact assigns a contact to a team member by its ID.
+//
+// It expects the contact ID as a URL parameter and a JSON payload matching the
+// ContactAssignRequest structure. The optional X-Actor header identifies who made the
+// change, and the optional If-Match header makes the change conditional on the contact version.
+// On success, it returns the updated contact with a 200 status code.
+func (h *ContactHandler) AssignContact(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Read the actor and the expected version, if any, from the headers.
+	actor, ok := actorHeader(c)
+	if !ok {
+		return
+	}
+	version, ok := optionalIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	// Bind the JSON payload to the ContactAssignRequest struct.
+	var req requests.ContactAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to assign the contact.
+	contact, err := h.service.AssignContact(c.Request.Context(), id, version, &req, actor)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated contact, its entity tag, and a success message.
+	setContactETag(c, contact)
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactAssigned),
+		Data:    responses.ContactResponseFromModel(contact),
+	})
+}
+
+// UnassignContact removes the assignee of a contact by its ID. The contact stays in its queue.
+//
+// It expects the contact ID as a URL parameter. The optional X-Actor header identifies who
+// made the change, and the optional If-Match header makes the change conditional on the
+// contact version.
+// On success, it returns the updated contact with a 200 status code.
+func (h *ContactHandler) UnassignContact(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Read the actor and the expected version, if any, from the headers.
+	actor, ok := actorHeader(c)
+	if !ok {
+		return
+	}
+	version, ok := optionalIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to unassign the contact.
+	contact, err := h.service.UnassignContact(c.Request.Context(), id, version, actor)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated contact, its entity tag, and a success message.
+	setContactETag(c, contact)
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactUnassigned),
+		Data:    responses.ContactResponseFromModel(contact),
+	})
+}
+
+// GetContactAssignments retrieves the assignment history of a contact by its ID.
+//
+// It expects the contact ID as a URL parameter.
+// If the ID is invalid or the contact is not found, it returns an appropriate error response.
+// On success, it returns the assignment changes, oldest first, with a 200 status code.
+func (h *ContactHandler) GetContactAssignments(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to retrieve the assignment history.
+	assignments, err := h.service.GetContactAssignments(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the assignment changes to response format.
+	assignmentResponses := make([]responses.ContactAssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		assignmentResponses = append(assignmentResponses, responses.ContactAssignmentResponseFromModel(&assignment))
+	}
+
+	// Respond with the assignment history and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgAssignmentsRetrieved),
+		Data:    assignmentResponses,
+	})
+}
+
+// AddContactTags attaches tags to a contact by its ID.
+//
+// It expects the contact ID as a URL parameter and a JSON payload matching the
+// ContactTagsRequest structure. Tags that the contact already has are left as they are.
+// If the ID is invalid, or the contact or a tag is not found, it returns an appropriate error response.
+// On success, it returns the contact with its tags with a 200 status code.
+func (h *ContactHandler) AddContactTags(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Bind the JSON payload to the ContactTagsRequest struct.
+	var req requests.ContactTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to attach the tags.
+	contact, err := h.service.AddContactTags(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the contact, its entity tag, and a success message.
+	setContactETag(c, contact)
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactTagsUpdated),
+		Data:    responses.ContactResponseFromModel(contact),
+	})
+}
+
+// RemoveContactTag detaches a tag from a contact.
+//
+// It expects the contact ID and the tag ID as URL parameters. Detaching a tag that the
+// contact does not have is not an error.
+// If an ID is invalid or the contact is not found, it returns an appropriate error response.
+// On success, it returns the contact with its remaining tags with a 200 status code.
+func (h *ContactHandler) RemoveContactTag(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Retrieve the 'tag_id' parameter from the URL.
+	tagID, ok := positiveIDParam(c, "tag_id")
+	if !ok {
+		return
+	}
+
+	// Use the service layer to detach the tag.
+	contact, err := h.service.RemoveContactTag(c.Request.Context(), id, tagID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the contact, its entity tag, and a success message.
+	setContactETag(c, contact)
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactTagsUpdated),
+		Data:    responses.ContactResponseFromModel(contact),
+	})
+}
+
+// BulkTagContacts attaches tags to and detaches tags from many contacts at once.
+//
+// It expects a JSON payload matching the BulkTagRequest structure. Either every contact is
+// changed or, if a contact or a tag is not found, none is.
+// On success, it returns the changed contacts with their tags with a 200 status code.
+func (h *ContactHandler) BulkTagContacts(c *gin.Context) {
+	var req requests.BulkTagRequest
+
+	// Bind the JSON payload to the BulkTagRequest struct.
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to change the tags of the contacts.
+	contacts, err := h.service.BulkTagContacts(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the contact models to response formats.
+	contactResponses := make([]responses.ContactResponse, 0, len(contacts))
+	for _, contact := range contacts {
+		contactResponses = append(contactResponses, responses.ContactResponseFromModel(&contact))
+	}
+
+	// Respond with the changed contacts and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgContactsTagged),
+		Data:    contactResponses,
+	})
+}
+
+// actorHeader returns the value of the X-Actor request header, which identifies who makes
+// a change. If the header is too long, it responds with a 400 status code and ok is false.
+func actorHeader(c *gin.Context) (actor string, ok bool) {
+	actor = strings.TrimSpace(c.GetHeader("X-Actor"))
+	if len(actor) > maxActorLength {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Code:    "BAD_REQUEST",
+			Message: translate(c, i18n.MsgInvalidActor),
+			Data:    nil,
+		})
+		return "", false
+	}
+	return actor, true
+}
+
+// requiredActorHeader returns the value of the X-Actor request header, like actorHeader,
+// except that a missing header also responds with a 400 status code.
+func requiredActorHeader(c *gin.Context) (actor string, ok bool) {
+	actor, ok = actorHeader(c)
+	if ok && actor == "" {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Code:    "BAD_REQUEST",
+			Message: translate(c, i18n.MsgActorRequired),
+			Data:    nil,
+		})
+		return "", false
+	}
+	return actor, ok
+}
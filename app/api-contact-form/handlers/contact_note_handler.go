@@ -0,0 +1,212 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// It defines the ContactNoteHandler struct, which provides methods to handle CRUD (Create,
+// Read, Update, Delete) operations for the internal notes operators leave on contacts.
+// The author of a note is identified by the X-Actor request header.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/requests"
+	"api-contact-form/responses"
+	"api-contact-form/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContactNoteHandler handles HTTP requests related to contact note operations.
+type ContactNoteHandler struct {
+	service services.ContactNoteService
+}
+
+// NewContactNoteHandler creates a new instance of ContactNoteHandler with the provided ContactNoteService.
+func NewContactNoteHandler(service services.ContactNoteService) *ContactNoteHandler {
+	return &ContactNoteHandler{service}
+}
+
+// CreateNote handles the creation of a new note on a contact.
+//
+// It expects the contact ID as a URL parameter, the author in the X-Actor header, and a
+// JSON payload matching the ContactNoteRequest structure.
+// Upon successful creation, it returns the created note with a 201 status code.
+func (h *ContactNoteHandler) CreateNote(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Identify the author.
+	author, ok := requiredActorHeader(c)
+	if !ok {
+		return
+	}
+
+	// Bind the JSON payload to the ContactNoteRequest struct.
+	var req requests.ContactNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to create the note.
+	note, err := h.service.CreateNote(c.Request.Context(), contactID, &req, author)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the created note and a success message.
+	c.JSON(http.StatusCreated, responses.APIResponse{
+		Code:    "CREATED",
+		Message: translate(c, i18n.MsgNoteCreated),
+		Data:    responses.ContactNoteResponseFromModel(note),
+	})
+}
+
+// GetNotes retrieves the notes of a contact, oldest first.
+//
+// It expects the contact ID as a URL parameter.
+// On success, it returns the list of notes with a 200 status code.
+func (h *ContactNoteHandler) GetNotes(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to retrieve the notes.
+	notes, err := h.service.GetNotes(c.Request.Context(), contactID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the note models to response formats.
+	noteResponses := make([]responses.ContactNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		noteResponses = append(noteResponses, responses.ContactNoteResponseFromModel(&note))
+	}
+
+	// Respond with the list of notes and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgNotesRetrieved),
+		Data:    noteResponses,
+	})
+}
+
+// UpdateNote edits a note of a contact.
+//
+// It expects the contact ID and the note ID as URL parameters, the editor in the X-Actor
+// header, and a JSON payload matching the ContactNoteRequest structure. The previous body
+// of the note is kept in its edit history.
+// On success, it returns the updated note with a 200 status code.
+func (h *ContactNoteHandler) UpdateNote(c *gin.Context) {
+	// Retrieve the 'id' and 'note_id' parameters from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+	noteID, ok := noteIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Identify the editor.
+	editor, ok := requiredActorHeader(c)
+	if !ok {
+		return
+	}
+
+	// Bind the JSON payload to the ContactNoteRequest struct.
+	var req requests.ContactNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to update the note.
+	note, err := h.service.UpdateNote(c.Request.Context(), contactID, noteID, &req, editor)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated note and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgNoteUpdated),
+		Data:    responses.ContactNoteResponseFromModel(note),
+	})
+}
+
+// DeleteNote permanently removes a note of a contact along with its edit history.
+//
+// It expects the contact ID and the note ID as URL parameters.
+// On success, it returns a success message with a 200 status code.
+func (h *ContactNoteHandler) DeleteNote(c *gin.Context) {
+	// Retrieve the 'id' and 'note_id' parameters from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+	noteID, ok := noteIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to delete the note.
+	if err := h.service.DeleteNote(c.Request.Context(), contactID, noteID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgNoteDeleted),
+		Data:    nil,
+	})
+}
+
+// GetNoteRevisions retrieves the edit history of a note of a contact, oldest first.
+//
+// It expects the contact ID and the note ID as URL parameters. Each revision holds the body
+// the note had before an edit, who made the edit, and when.
+// On success, it returns the list of revisions with a 200 status code.
+func (h *ContactNoteHandler) GetNoteRevisions(c *gin.Context) {
+	// Retrieve the 'id' and 'note_id' parameters from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+	noteID, ok := noteIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to retrieve the edit history.
+	revisions, err := h.service.GetNoteRevisions(c.Request.Context(), contactID, noteID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the revision models to response formats.
+	revisionResponses := make([]responses.ContactNoteRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionResponses = append(revisionResponses, responses.ContactNoteRevisionResponseFromModel(&revision))
+	}
+
+	// Respond with the edit history and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgNoteRevisionsRetrieved),
+		Data:    revisionResponses,
+	})
+}
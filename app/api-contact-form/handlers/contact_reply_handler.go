@@ -0,0 +1,103 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// It defines the ContactReplyHandler struct, which provides methods to reply by email to the
+// submitter of a contact and to read the conversation held with them. The sender of a reply
+// is identified by the X-Actor request header.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/requests"
+	"api-contact-form/responses"
+	"api-contact-form/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContactReplyHandler handles HTTP requests related to email replies to contacts.
+type ContactReplyHandler struct {
+	service services.ContactReplyService
+}
+
+// NewContactReplyHandler creates a new instance of ContactReplyHandler with the provided ContactReplyService.
+func NewContactReplyHandler(service services.ContactReplyService) *ContactReplyHandler {
+	return &ContactReplyHandler{service}
+}
+
+// SendReply handles emailing a reply to the submitter of a contact.
+//
+// It expects the contact ID as a URL parameter, an optional sender in the X-Actor header,
+// and a JSON payload matching the ContactReplyRequest structure.
+// Upon successful delivery to the mail server, it returns the reply with a 201 status code.
+// If the mail server does not accept the reply, it responds with a 502 status code and the
+// reply stays in the conversation as failed.
+func (h *ContactReplyHandler) SendReply(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Identify the sender.
+	actor, ok := actorHeader(c)
+	if !ok {
+		return
+	}
+
+	// Bind the JSON payload to the ContactReplyRequest struct.
+	var req requests.ContactReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to send the reply.
+	reply, err := h.service.SendReply(c.Request.Context(), contactID, &req, actor)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the sent reply and a success message.
+	c.JSON(http.StatusCreated, responses.APIResponse{
+		Code:    "CREATED",
+		Message: translate(c, i18n.MsgReplySent),
+		Data:    responses.ContactThreadMessageResponseFromModel(reply),
+	})
+}
+
+// GetThread retrieves the emails exchanged with the submitter of a contact, oldest first.
+//
+// It expects the contact ID as a URL parameter.
+// On success, it returns the list of emails with a 200 status code.
+func (h *ContactReplyHandler) GetThread(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	contactID, ok := contactIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Use the service layer to retrieve the conversation.
+	thread, err := h.service.GetThread(c.Request.Context(), contactID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the thread message models to response formats.
+	messageResponses := make([]responses.ContactThreadMessageResponse, 0, len(thread))
+	for _, message := range thread {
+		messageResponses = append(messageResponses, responses.ContactThreadMessageResponseFromModel(&message))
+	}
+
+	// Respond with the conversation and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgThreadRetrieved),
+		Data:    messageResponses,
+	})
+}
@@ -0,0 +1,217 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// This file provides the central mapper that converts service errors into API responses,
+// so that every handler reports missing resources, invalid input, conflicts, timeouts,
+// and unexpected failures the same way. Validation failures list the invalid fields in
+// the errors array of the response. Messages are localized into the language of the request.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/responses"
+	"api-contact-form/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// respondError responds with the API response matching a service error.
+//
+// The error is classified as follows:
+//   - services.ErrValidation: 422 with code VALIDATION_ERROR.
+//   - services.ErrInvalidCursor: 400 with code BAD_REQUEST.
+//   - services.ErrInvalidAttachment: 422 with code INVALID_ATTACHMENT.
+//   - services.ErrNotFound: 404 with code NOT_FOUND.
+//   - services.ErrAttachmentQuarantined: 403 with code ATTACHMENT_QUARANTINED.
+//   - services.ErrInvalidStatusTransition: 409 with code INVALID_STATUS_TRANSITION.
+//   - services.ErrConflict: 409 with code CONFLICT.
+//   - services.ErrPreconditionFailed: 412 with code PRECONDITION_FAILED.
+//   - services.ErrMailUnavailable: 503 with code MAIL_UNAVAILABLE.
+//   - services.ErrMailDelivery: 502 with code MAIL_DELIVERY_FAILED.
+//   - services.ErrScanUnavailable: 503 with code SCAN_UNAVAILABLE.
+//   - context.DeadlineExceeded: 504 with code GATEWAY_TIMEOUT.
+//   - context.Canceled: 503 with code SERVICE_UNAVAILABLE.
+//   - Anything else: 500 with code INTERNAL_SERVER_ERROR. The error is logged, and a generic
+//     message is returned so that database error details never reach the client.
+func respondError(c *gin.Context, err error) {
+	status, code, key := http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", i18n.MsgInternalServerError
+	var fieldErrs []responses.FieldError
+
+	switch {
+	case errors.Is(err, services.ErrValidation):
+		status, code, key = http.StatusUnprocessableEntity, "VALIDATION_ERROR", i18n.MsgValidationFailed
+		fieldErrs = fieldErrors(c, err)
+	case errors.Is(err, services.ErrInvalidCursor):
+		status, code, key = http.StatusBadRequest, "BAD_REQUEST", i18n.MsgInvalidCursor
+	case errors.Is(err, services.ErrInvalidAttachment):
+		status, code, key = http.StatusUnprocessableEntity, "INVALID_ATTACHMENT", domainMessageKey(err, i18n.MsgValidationFailed)
+	case errors.Is(err, services.ErrNotFound):
+		status, code, key = http.StatusNotFound, "NOT_FOUND", domainMessageKey(err, i18n.MsgNotFound)
+	case errors.Is(err, services.ErrAttachmentQuarantined):
+		status, code, key = http.StatusForbidden, "ATTACHMENT_QUARANTINED", i18n.MsgAttachmentQuarantined
+	case errors.Is(err, services.ErrInvalidStatusTransition):
+		status, code, key = http.StatusConflict, "INVALID_STATUS_TRANSITION", domainMessageKey(err, i18n.MsgConflict)
+	case errors.Is(err, services.ErrConflict):
+		status, code, key = http.StatusConflict, "CONFLICT", domainMessageKey(err, i18n.MsgConflict)
+	case errors.Is(err, services.ErrPreconditionFailed):
+		status, code, key = http.StatusPreconditionFailed, "PRECONDITION_FAILED", domainMessageKey(err, i18n.MsgPreconditionFailed)
+	case errors.Is(err, services.ErrMailUnavailable):
+		status, code, key = http.StatusServiceUnavailable, "MAIL_UNAVAILABLE", i18n.MsgMailUnavailable
+	case errors.Is(err, services.ErrMailDelivery):
+		status, code, key = http.StatusBadGateway, "MAIL_DELIVERY_FAILED", i18n.MsgMailDeliveryFailed
+	case errors.Is(err, services.ErrScanUnavailable):
+		status, code, key = http.StatusServiceUnavailable, "SCAN_UNAVAILABLE", i18n.MsgScanUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		status, code, key = http.StatusGatewayTimeout, "GATEWAY_TIMEOUT", i18n.MsgDatabaseTimeout
+	case errors.Is(err, context.Canceled):
+		status, code, key = http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", i18n.MsgRequestCancelled
+	default:
+		log.Printf("Unexpected error on %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+
+	c.JSON(status, responses.APIResponse{
+		Code:    code,
+		Message: translate(c, key, domainMessageArgs(err)...),
+		Data:    nil,
+		Errors:  fieldErrs,
+	})
+}
+
+// respondBindingError responds to a request body that could not be bound.
+//
+// If the body is well-formed but some fields are invalid, or have the wrong JSON type,
+// it responds with a 422 status code and the invalid fields. If the body is not valid
+// JSON, it responds with a 400 status code.
+func respondBindingError(c *gin.Context, err error) {
+	if fieldErrs := fieldErrors(c, err); len(fieldErrs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, responses.APIResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: translate(c, i18n.MsgValidationFailed),
+			Data:    nil,
+			Errors:  fieldErrs,
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, responses.APIResponse{
+		Code:    "BAD_REQUEST",
+		Message: translate(c, i18n.MsgMalformedJSON),
+		Data:    nil,
+	})
+}
+
+// respondQueryError responds to query parameters that could not be bound with a 400
+// status code, listing the invalid parameters when they failed validation.
+func respondQueryError(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, responses.APIResponse{
+		Code:    "BAD_REQUEST",
+		Message: translate(c, i18n.MsgInvalidQuery),
+		Data:    nil,
+		Errors:  fieldErrors(c, err),
+	})
+}
+
+// fieldErrors converts validator errors, the field errors of services, and JSON type errors
+// into field errors, with messages in the language of the request.
+// It returns nil if err carries no field-level details.
+func fieldErrors(c *gin.Context, err error) []responses.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fieldErrs := make([]responses.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fieldErrs = append(fieldErrs, responses.FieldError{
+				Field:   fe.Field(),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: i18n.TranslateFieldError(language(c), fe),
+			})
+		}
+		return fieldErrs
+	}
+
+	var serviceFieldErrs services.FieldErrors
+	if errors.As(err, &serviceFieldErrs) {
+		fieldErrs := make([]responses.FieldError, 0, len(serviceFieldErrs))
+		for _, fe := range serviceFieldErrs {
+			fieldErrs = append(fieldErrs, responses.FieldError{
+				Field:   fe.Field,
+				Rule:    fe.Rule,
+				Param:   fe.Param,
+				Message: translate(c, fe.MessageKey, fe.Args...),
+			})
+		}
+		return fieldErrs
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []responses.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeErr.Type.String(),
+			Message: translate(c, i18n.MsgFieldType, typeErr.Field, typeErr.Type.String()),
+		}}
+	}
+
+	return nil
+}
+
+// domainMessageKey returns the message key of a services.Error, or the fallback key if
+// err is not a services.Error.
+func domainMessageKey(err error, fallback string) string {
+	var domainErr *services.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.MessageKey
+	}
+	return fallback
+}
+
+// domainMessageArgs returns the message arguments of a services.Error, or nil if err is
+// not a services.Error.
+func domainMessageArgs(err error) []interface{} {
+	var domainErr *services.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Args
+	}
+	return nil
+}
+
+// respondFormError responds to a multipart form that could not be bound.
+//
+// If the form is larger than allowed, it responds with a 413 status code. If the form is
+// well-formed but some fields are invalid, it responds with a 422 status code and the invalid
+// fields. If the form is malformed, it responds with a 400 status code.
+func respondFormError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, responses.APIResponse{
+			Code:    "REQUEST_TOO_LARGE",
+			Message: translate(c, i18n.MsgRequestTooLarge),
+			Data:    nil,
+		})
+		return
+	}
+	if fieldErrs := fieldErrors(c, err); len(fieldErrs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, responses.APIResponse{
+			Code:    "VALIDATION_ERROR",
+			Message: translate(c, i18n.MsgValidationFailed),
+			Data:    nil,
+			Errors:  fieldErrs,
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, responses.APIResponse{
+		Code:    "BAD_REQUEST",
+		Message: translate(c, i18n.MsgInvalidMultipartForm),
+		Data:    nil,
+	})
+}
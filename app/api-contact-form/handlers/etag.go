@@ -0,0 +1,76 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// This file provides the ETag and If-Match handling used for optimistic concurrency control
+// of contacts: responses carry the version of a contact as its ETag, and requests that change
+// a contact must send that ETag back in the If-Match header.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/models"
+	"api-contact-form/responses"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contactETag returns the strong entity tag of a contact, derived from its version.
+func contactETag(contact *models.Contact) string {
+	return `"` + strconv.FormatUint(uint64(contact.Version), 10) + `"`
+}
+
+// setContactETag sets the ETag response header to the entity tag of a contact.
+func setContactETag(c *gin.Context, contact *models.Contact) {
+	c.Header("ETag", contactETag(contact))
+}
+
+// ifMatchVersion returns the contact version expected by the If-Match request header.
+//
+// The header must hold either a single entity tag previously returned in an ETag header,
+// or "*" to match any version, in which case the returned version is 0.
+// If the header is missing, it responds with a 428 status code. If the header cannot match
+// any contact version, such as a weak or malformed entity tag, it responds with a 412 status
+// code. In both cases, ok is false and the handler should return.
+func ifMatchVersion(c *gin.Context) (version uint, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, responses.APIResponse{
+			Code:    "PRECONDITION_REQUIRED",
+			Message: translate(c, i18n.MsgIfMatchRequired),
+			Data:    nil,
+		})
+		return 0, false
+	}
+
+	if header == "*" {
+		return 0, true
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err == nil && strings.HasPrefix(header, `"`) {
+		if parsed, err := strconv.ParseUint(tag, 10, 0); err == nil && parsed > 0 {
+			return uint(parsed), true
+		}
+	}
+
+	c.JSON(http.StatusPreconditionFailed, responses.APIResponse{
+		Code:    "PRECONDITION_FAILED",
+		Message: translate(c, i18n.MsgPreconditionFailed),
+		Data:    nil,
+	})
+	return 0, false
+}
+
+// optionalIfMatchVersion returns the contact version expected by the If-Match request header,
+// like ifMatchVersion, except that a missing header matches any version.
+func optionalIfMatchVersion(c *gin.Context) (version uint, ok bool) {
+	if strings.TrimSpace(c.GetHeader("If-Match")) == "" {
+		return 0, true
+	}
+	return ifMatchVersion(c)
+}
@@ -0,0 +1,160 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// This file builds the configuration of a form that clients render it from: the texts of the
+// form and its fields in the language of the request, the rules of the fields, and the error
+// messages displayed when a value breaks a rule. Configurations are cached by clients and
+// proxies, and revalidated with their ETag in the If-None-Match header.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/models"
+	"api-contact-form/responses"
+	"api-contact-form/services"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// formConfig builds the configuration of a form, with its texts in the language lang.
+// Texts without a translation into lang fall back to those of the form.
+func formConfig(c *gin.Context, form *models.Form, lang string) responses.FormConfigResponse {
+	config := responses.FormConfigResponse{
+		Slug:        form.Slug,
+		Language:    lang,
+		Name:        form.Name,
+		Description: form.Description,
+		SubmitURL:   "/forms/" + form.Slug + "/submissions",
+		ThankYouURL: form.ThankYouURL,
+		Fields:      make([]responses.FormFieldConfigResponse, 0, len(form.Fields)),
+		Messages: map[string]string{
+			"submit":   translate(c, i18n.MsgFormSubmitLabel),
+			"optional": translate(c, i18n.MsgFormOptionalLabel),
+			"success":  translate(c, i18n.MsgFormSuccessText),
+			"failure":  translate(c, i18n.MsgFormFailureText),
+		},
+	}
+	if translation, ok := form.Translations[lang]; ok {
+		config.Name = textOr(translation.Name, config.Name)
+		config.Description = textOr(translation.Description, config.Description)
+	}
+
+	for _, field := range form.Fields {
+		config.Fields = append(config.Fields, formFieldConfig(c, field, lang))
+	}
+	return config
+}
+
+// formFieldConfig builds the configuration of a form field, with its texts in the language lang.
+func formFieldConfig(c *gin.Context, field models.FormField, lang string) responses.FormFieldConfigResponse {
+	translation := field.Translations[lang]
+	config := responses.FormFieldConfigResponse{
+		Name:        field.Name,
+		Label:       textOr(translation.Label, field.Label),
+		Placeholder: textOr(translation.Placeholder, field.Placeholder),
+		Type:        field.Type,
+		Rules:       responses.FormFieldRulesResponse{Required: field.Required},
+		Messages:    map[string]string{},
+	}
+
+	// List the options with their texts
+	optionLabels := make([]string, 0, len(field.Options))
+	for _, option := range field.Options {
+		label := textOr(translation.Options[option], option)
+		config.Options = append(config.Options, responses.FormOptionResponse{Value: option, Label: label})
+		optionLabels = append(optionLabels, label)
+	}
+
+	// Describe the rules of the field and their messages
+	if field.Required {
+		config.Messages["required"] = translate(c, i18n.MsgFieldRequired, config.Label)
+	}
+	switch field.Type {
+	case models.FormFieldSelect, models.FormFieldMultiselect:
+		config.Messages["oneof"] = translate(c, i18n.MsgFieldInvalidOption, config.Label, strings.Join(optionLabels, ", "))
+		return config
+	case models.FormFieldNumber:
+		config.Messages["type"] = translate(c, i18n.MsgFieldType, config.Label, "number")
+		return config
+	case models.FormFieldCheckbox:
+		return config
+	case models.FormFieldEmail:
+		config.Messages["email"] = translate(c, i18n.MsgFieldInvalidEmail, config.Label)
+	case models.FormFieldURL:
+		config.Messages["url"] = translate(c, i18n.MsgFieldInvalidURL, config.Label)
+	}
+
+	// The other types hold text
+	config.Rules.MaxLength = services.FormFieldMaxLength(field)
+	config.Messages["max"] = translate(c, i18n.MsgFieldTooLong, config.Label, config.Rules.MaxLength)
+	if field.Pattern != "" {
+		config.Rules.Pattern = field.Pattern
+		config.Messages["pattern"] = translate(c, i18n.MsgFieldPatternMismatch, config.Label)
+	}
+	return config
+}
+
+// textOr returns the text, or the fallback if the text is empty.
+func textOr(text string, fallback string) string {
+	if text == "" {
+		return fallback
+	}
+	return text
+}
+
+// bodyETag returns the strong entity tag of a response body, derived from its SHA-256 digest.
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ifNoneMatch reports whether the If-None-Match request header matches the entity tag, in
+// which case the client already holds the current representation.
+//
+// The header may list several entity tags separated by commas, or hold "*" to match any.
+// Entity tags are compared weakly, so a weak tag matches the strong tag of the same value.
+func ifNoneMatch(c *gin.Context, etag string) bool {
+	header := strings.TrimSpace(c.GetHeader("If-None-Match"))
+	if header == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// setCacheControl sets the Cache-Control response header of a public resource that clients and
+// proxies may reuse for maxAge before revalidating it. Zero requires revalidation on every use.
+func setCacheControl(c *gin.Context, maxAge time.Duration) {
+	if maxAge <= 0 {
+		c.Header("Cache-Control", "no-cache")
+		return
+	}
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge/time.Second)))
+}
+
+// respondCacheable responds with a 200 status code and the JSON body, or with a 304 status
+// code and no body if the If-None-Match request header matches the ETag of the body.
+func respondCacheable(c *gin.Context, body []byte, maxAge time.Duration) {
+	etag := bodyETag(body)
+	c.Header("ETag", etag)
+	c.Header("Vary", "Accept-Language")
+	setCacheControl(c, maxAge)
+
+	if ifNoneMatch(c, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
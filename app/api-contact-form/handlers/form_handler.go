@@ -0,0 +1,221 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// It defines the FormHandler struct, which provides methods to handle CRUD (Create, Read,
+// Update, Delete) operations for forms, the configurations clients render forms from, and the
+// submissions of forms.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/requests"
+	"api-contact-form/responses"
+	"api-contact-form/services"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FormHandler handles HTTP requests related to form operations.
+type FormHandler struct {
+	service      services.FormService
+	configMaxAge time.Duration
+}
+
+// NewFormHandler creates a new instance of FormHandler with the provided FormService.
+// Clients and proxies may reuse form configurations for configMaxAge before revalidating them;
+// zero requires revalidation on every use.
+func NewFormHandler(service services.FormService, configMaxAge time.Duration) *FormHandler {
+	return &FormHandler{service, configMaxAge}
+}
+
+// CreateForm handles the creation of a new form.
+//
+// It expects a JSON payload matching the FormRequest structure.
+// Upon successful creation, it returns the created form with a 201 status code.
+// If the slug is already taken, it responds with a 409 status code.
+func (h *FormHandler) CreateForm(c *gin.Context) {
+	var req requests.FormRequest
+
+	// Bind the JSON payload to the FormRequest struct.
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to create a new form.
+	form, err := h.service.CreateForm(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the created form and a success message.
+	c.JSON(http.StatusCreated, responses.APIResponse{
+		Code:    "CREATED",
+		Message: translate(c, i18n.MsgFormCreated),
+		Data:    responses.FormResponseFromModel(form),
+	})
+}
+
+// GetForms retrieves every form, ordered by slug.
+//
+// On success, it returns the list of forms with a 200 status code.
+func (h *FormHandler) GetForms(c *gin.Context) {
+	// Use the service layer to retrieve the forms.
+	forms, err := h.service.GetForms(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the form models to response formats.
+	formResponses := make([]responses.FormResponse, 0, len(forms))
+	for _, form := range forms {
+		formResponses = append(formResponses, responses.FormResponseFromModel(&form))
+	}
+
+	// Respond with the list of forms and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgFormsRetrieved),
+		Data:    formResponses,
+	})
+}
+
+// GetForm retrieves a single form by its slug.
+//
+// It expects the form slug as a URL parameter.
+// If the form is not found, it returns an appropriate error response.
+// On success, it returns the form with a 200 status code.
+func (h *FormHandler) GetForm(c *gin.Context) {
+	// Use the service layer to fetch the form by slug.
+	form, err := h.service.GetForm(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the form and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgFormRetrieved),
+		Data:    responses.FormResponseFromModel(form),
+	})
+}
+
+// GetFormConfig retrieves the configuration that clients render a form from, by its slug.
+//
+// It expects the form slug as a URL parameter. The texts of the configuration are in the
+// language of the request.
+// If the form is not found, it returns an appropriate error response.
+// On success, it returns the configuration and its ETag with a 200 status code, or a 304 status
+// code without a body if the If-None-Match header holds that ETag.
+func (h *FormHandler) GetFormConfig(c *gin.Context) {
+	// Use the service layer to fetch the form by slug.
+	form, err := h.service.GetForm(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Build the configuration in the language of the request.
+	body, err := json.Marshal(responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgFormConfigRetrieved),
+		Data:    formConfig(c, form, language(c)),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the configuration, unless the client already holds it.
+	respondCacheable(c, body, h.configMaxAge)
+}
+
+// UpdateForm updates an existing form by its slug.
+//
+// It expects the form slug as a URL parameter and a JSON payload matching the FormRequest structure.
+// If the form is not found, the new slug is already taken, or the update changes the slug or
+// the rules of the fields of the default form, it returns an appropriate error response.
+// On success, it returns the updated form with a 200 status code.
+func (h *FormHandler) UpdateForm(c *gin.Context) {
+	// Bind the JSON payload to the FormRequest struct.
+	var req requests.FormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to update the form.
+	form, err := h.service.UpdateForm(c.Request.Context(), c.Param("slug"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated form and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgFormUpdated),
+		Data:    responses.FormResponseFromModel(form),
+	})
+}
+
+// DeleteForm permanently removes a form by its slug.
+//
+// It expects the form slug as a URL parameter.
+// If the form is not found, contacts were submitted through it, or it is the default form,
+// it returns an appropriate error response.
+// On success, it returns a success message with a 200 status code.
+func (h *FormHandler) DeleteForm(c *gin.Context) {
+	// Use the service layer to delete the form.
+	if err := h.service.DeleteForm(c.Request.Context(), c.Param("slug")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgFormDeleted),
+		Data:    nil,
+	})
+}
+
+// SubmitForm handles a submission of a form, creating a new contact.
+//
+// It expects the form slug as a URL parameter and a JSON object holding the value of each
+// field by field name.
+// If the form is not found, it responds with a 404 status code. If values break the rules
+// of their fields, or do not belong to a field of the form, it responds with a 422 status
+// code listing them.
+// Upon successful creation, it returns the public view of the created contact with a 201 status code.
+func (h *FormHandler) SubmitForm(c *gin.Context) {
+	var values map[string]interface{}
+
+	// Bind the JSON payload, which must be a JSON object, to the values.
+	if err := c.ShouldBindJSON(&values); err != nil || values == nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to create a contact from the submission.
+	contact, err := h.service.SubmitForm(c.Request.Context(), c.Param("slug"), values)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the created contact and a success message.
+	c.JSON(http.StatusCreated, responses.APIResponse{
+		Code:    "CREATED",
+		Message: translate(c, i18n.MsgFormSubmitted),
+		Data:    responses.PublicContactResponseFromModel(contact),
+	})
+}
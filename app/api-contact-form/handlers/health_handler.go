@@ -8,6 +8,7 @@
 package handlers
 
 import (
+	"api-contact-form/i18n"
 	"api-contact-form/responses"
 	"net/http"
 
@@ -36,6 +37,6 @@ func NewHealthHandler() *HealthHandler {
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, responses.APIResponse{
 		Code:    "SUCCESS",
-		Message: "API is running.",
+		Message: translate(c, i18n.MsgHealthOK),
 	})
 }
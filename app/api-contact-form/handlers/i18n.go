@@ -0,0 +1,28 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// This file provides helpers that localize response messages into the language negotiated
+// for the request by the Language middleware.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// language returns the language negotiated for the request, or i18n.DefaultLanguage
+// if the Language middleware did not run.
+func language(c *gin.Context) string {
+	if lang := c.GetString(i18n.ContextKey); lang != "" {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
+
+// translate returns the catalog message with the given key in the language of the request.
+func translate(c *gin.Context, key string, args ...interface{}) string {
+	return i18n.T(language(c), key, args...)
+}
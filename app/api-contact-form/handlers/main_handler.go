@@ -8,6 +8,7 @@
 package handlers
 
 import (
+	"api-contact-form/i18n"
 	"api-contact-form/responses"
 	"net/http"
 
@@ -35,6 +36,6 @@ func NewMainHandler() *MainHandler {
 func (h *MainHandler) MainHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, responses.APIResponse{
 		Code:    "SUCCESS",
-		Message: "API Contact Form is running.",
+		Message: translate(c, i18n.MsgAPIRunning),
 	})
 }
@@ -0,0 +1,49 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// This file provides the helpers that read resource IDs from URL parameters.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/responses"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contactIDParam returns the contact ID held by the 'id' URL parameter. If the parameter
+// is not a positive integer, it responds with a 400 status code and ok is false.
+func contactIDParam(c *gin.Context) (id uint, ok bool) {
+	return positiveIDParam(c, "id")
+}
+
+// noteIDParam returns the note ID held by the 'note_id' URL parameter. If the parameter
+// is not a positive integer, it responds with a 400 status code and ok is false.
+func noteIDParam(c *gin.Context) (id uint, ok bool) {
+	return positiveIDParam(c, "note_id")
+}
+
+// attachmentIDParam returns the attachment ID held by the 'attachment_id' URL parameter. If
+// the parameter is not a positive integer, it responds with a 400 status code and ok is false.
+func attachmentIDParam(c *gin.Context) (id uint, ok bool) {
+	return positiveIDParam(c, "attachment_id")
+}
+
+// positiveIDParam returns the ID held by the named URL parameter. If the parameter is not
+// a positive integer, it responds with a 400 status code and ok is false.
+func positiveIDParam(c *gin.Context, name string) (id uint, ok bool) {
+	parsed, err := strconv.ParseUint(c.Param(name), 10, 0)
+	if err != nil || parsed == 0 {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Code:    "BAD_REQUEST",
+			Message: translate(c, i18n.MsgInvalidID),
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(parsed), true
+}
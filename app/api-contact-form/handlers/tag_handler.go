@@ -0,0 +1,170 @@
+// Package handlers contains the HTTP handler implementations for various endpoints.
+//
+// It defines the TagHandler struct, which provides methods to handle CRUD (Create, Read,
+// Update, Delete) operations for the tags that categorize contacts.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package handlers
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/requests"
+	"api-contact-form/responses"
+	"api-contact-form/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler handles HTTP requests related to tag operations.
+type TagHandler struct {
+	service services.TagService
+}
+
+// NewTagHandler creates a new instance of TagHandler with the provided TagService.
+func NewTagHandler(service services.TagService) *TagHandler {
+	return &TagHandler{service}
+}
+
+// CreateTag handles the creation of a new tag.
+//
+// It expects a JSON payload matching the TagRequest structure.
+// Upon successful creation, it returns the created tag with a 201 status code.
+// If the name is already taken, it responds with a 409 status code.
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req requests.TagRequest
+
+	// Bind the JSON payload to the TagRequest struct.
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to create a new tag.
+	tag, err := h.service.CreateTag(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the created tag and a success message.
+	c.JSON(http.StatusCreated, responses.APIResponse{
+		Code:    "CREATED",
+		Message: translate(c, i18n.MsgTagCreated),
+		Data:    responses.TagResponseFromModel(tag),
+	})
+}
+
+// GetTags retrieves every tag, ordered by name.
+//
+// On success, it returns the list of tags with a 200 status code.
+func (h *TagHandler) GetTags(c *gin.Context) {
+	// Use the service layer to retrieve the tags.
+	tags, err := h.service.GetTags(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Convert the tag models to response formats.
+	tagResponses := make([]responses.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		tagResponses = append(tagResponses, responses.TagResponseFromModel(&tag))
+	}
+
+	// Respond with the list of tags and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgTagsRetrieved),
+		Data:    tagResponses,
+	})
+}
+
+// GetTag retrieves a single tag by its ID.
+//
+// It expects the tag ID as a URL parameter.
+// If the ID is invalid or the tag is not found, it returns an appropriate error response.
+// On success, it returns the tag with a 200 status code.
+func (h *TagHandler) GetTag(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := positiveIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Use the service layer to fetch the tag by ID.
+	tag, err := h.service.GetTag(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the tag and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgTagRetrieved),
+		Data:    responses.TagResponseFromModel(tag),
+	})
+}
+
+// UpdateTag updates the name and color of an existing tag by its ID.
+//
+// It expects the tag ID as a URL parameter and a JSON payload matching the TagRequest structure.
+// If the ID is invalid, the tag is not found, or the name is already taken, it returns an
+// appropriate error response.
+// On success, it returns the updated tag with a 200 status code.
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := positiveIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Bind the JSON payload to the TagRequest struct.
+	var req requests.TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Use the service layer to update the tag.
+	tag, err := h.service.UpdateTag(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with the updated tag and a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgTagUpdated),
+		Data:    responses.TagResponseFromModel(tag),
+	})
+}
+
+// DeleteTag permanently removes a tag by its ID, detaching it from every contact.
+//
+// It expects the tag ID as a URL parameter.
+// If the ID is invalid or the tag is not found, it returns an appropriate error response.
+// On success, it returns a success message with a 200 status code.
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	// Retrieve the 'id' parameter from the URL.
+	id, ok := positiveIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Use the service layer to delete the tag.
+	if err := h.service.DeleteTag(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Respond with a success message.
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Code:    "SUCCESS",
+		Message: translate(c, i18n.MsgTagDeleted),
+		Data:    nil,
+	})
+}
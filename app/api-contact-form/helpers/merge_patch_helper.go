@@ -0,0 +1,72 @@
+// Package helpers provides utility functions for the API Contact Form application.
+//
+// This file implements JSON Merge Patch (RFC 7396), used for partial updates of resources.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package helpers
+
+import (
+	"reflect"
+)
+
+// MergePatch applies a JSON Merge Patch (RFC 7396) to a decoded JSON document.
+//
+// Members of the patch replace the members of the target with the same name, members set
+// to null are removed from the target, and nested objects are merged recursively. A patch
+// that is not an object replaces the target entirely. The target is modified in place when
+// it is an object.
+//
+// Parameters:
+//   - target: The decoded JSON document to patch, as produced by encoding/json.
+//   - patch: The decoded JSON merge patch.
+//
+// Returns:
+//   - interface{}: The patched document.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = MergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
+
+// PatchedFields returns the Go names of the struct fields that a merge patch touches.
+// Fields are matched by their request names, as returned by RequestFieldName, so that the
+// result can be passed to validator.Validate.StructPartial.
+//
+// Parameters:
+//   - s: The struct, or pointer to struct, the patch applies to.
+//   - patch: The decoded JSON merge patch.
+//
+// Returns:
+//   - []string: The Go names of the fields present in the patch, in declaration order.
+func PatchedFields(s interface{}, patch map[string]interface{}) []string {
+	t := reflect.TypeOf(s)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := patch[RequestFieldName(field)]; ok {
+			fields = append(fields, field.Name)
+		}
+	}
+	return fields
+}
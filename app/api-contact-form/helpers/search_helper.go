@@ -0,0 +1,86 @@
+// Package helpers provides utility functions for the API Contact Form application.
+//
+// This file provides functions for splitting search queries into terms and producing
+// highlighted snippets of the text that matched a search.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package helpers
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchTerms splits a search query into its whitespace-separated terms.
+//
+// Parameters:
+//   - query: The raw search query.
+//
+// Returns:
+//   - A slice of the non-empty terms in the query.
+func SearchTerms(query string) []string {
+	return strings.Fields(query)
+}
+
+// HighlightSnippet extracts a snippet of text around the first occurrence of any of the
+// search terms and wraps every occurrence within the snippet in <mark></mark> tags.
+// The rest of the snippet is HTML-escaped so that it is safe to render as HTML.
+//
+// Parameters:
+//   - text: The text to extract the snippet from.
+//   - terms: The search terms to highlight. Matching is case-insensitive.
+//   - radius: The number of bytes of context to keep on each side of the first match.
+//     A radius of zero or less keeps the whole text.
+//
+// Returns:
+//   - The highlighted snippet, or an empty string if none of the terms occur in the text.
+func HighlightSnippet(text string, terms []string, radius int) string {
+	if len(terms) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	pattern := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+	loc := pattern.FindStringIndex(text)
+	if loc == nil {
+		return ""
+	}
+
+	// Widen the first match by the radius, keeping the boundaries on whole runes.
+	start, end := 0, len(text)
+	if radius > 0 {
+		start = max(0, loc[0]-radius)
+		for start > 0 && !utf8.RuneStart(text[start]) {
+			start--
+		}
+		end = min(len(text), loc[1]+radius)
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+	}
+	snippet := text[start:end]
+
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("…")
+	}
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(snippet, -1) {
+		builder.WriteString(html.EscapeString(snippet[last:match[0]]))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(snippet[match[0]:match[1]]))
+		builder.WriteString("</mark>")
+		last = match[1]
+	}
+	builder.WriteString(html.EscapeString(snippet[last:]))
+	if end < len(text) {
+		builder.WriteString("…")
+	}
+	return builder.String()
+}
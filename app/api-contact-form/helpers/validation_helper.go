@@ -0,0 +1,34 @@
+// Package helpers provides utility functions for the API Contact Form application.
+//
+// This file provides helpers that make validator errors presentable to API clients,
+// by naming fields as they appear in the request.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package helpers
+
+import (
+	"reflect"
+	"strings"
+)
+
+// RequestFieldName returns the name of a struct field as it appears in a request.
+// It is meant to be registered with validator.Validate.RegisterTagNameFunc, so that
+// validation errors report the JSON name, or the query parameter name, of a field.
+//
+// Parameters:
+//   - field: The struct field to name.
+//
+// Returns:
+//   - string: The name from the json tag, or from the form tag if there is no json tag.
+//     Returns "-" for fields excluded from the request, and the Go field name if neither
+//     tag is present.
+func RequestFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name := strings.SplitN(field.Tag.Get(key), ",", 2)[0]
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
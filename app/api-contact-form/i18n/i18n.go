@@ -0,0 +1,99 @@
+// Package i18n provides localized messages for the API Contact Form application.
+//
+// It holds the message catalogs for every supported language, negotiates the language of a
+// request from the lang query parameter or the Accept-Language header, and translates
+// validation errors into the negotiated language.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// English is the code of the English language.
+	English = "en"
+	// Indonesian is the code of the Bahasa Indonesia language.
+	Indonesian = "id"
+	// DefaultLanguage is the language used when a request does not ask for a supported one.
+	DefaultLanguage = English
+	// ContextKey is the key under which the negotiated language is stored in a request context.
+	ContextKey = "lang"
+)
+
+// supportedTags lists the supported languages. The first entry is the default language.
+var supportedTags = []language.Tag{
+	language.English,
+	language.Indonesian,
+}
+
+// matcher picks the best supported language for a list of requested languages.
+var matcher = language.NewMatcher(supportedTags)
+
+// Negotiate returns the supported language that best matches a request.
+//
+// Parameters:
+//   - lang: The value of the lang query parameter, which takes precedence if not empty.
+//   - acceptLanguage: The value of the Accept-Language header.
+//
+// Returns:
+//   - string: The code of the negotiated language, or DefaultLanguage if nothing matches.
+func Negotiate(lang, acceptLanguage string) string {
+	var requested []language.Tag
+	if tag, err := language.Parse(lang); err == nil {
+		requested = append(requested, tag)
+	}
+	if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+		requested = append(requested, tags...)
+	}
+
+	_, index, confidence := matcher.Match(requested...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+
+	base, _ := supportedTags[index].Base()
+	return base.String()
+}
+
+// T returns the message with the given key in the given language.
+// Messages missing from the catalog of the language fall back to DefaultLanguage, and
+// unknown keys are returned as is.
+//
+// Parameters:
+//   - lang: The code of the language, such as "en" or "id".
+//   - key: The key of the message in the catalog.
+//   - args: Optional arguments formatted into the message using fmt.Sprintf verbs.
+//
+// Returns:
+//   - string: The localized message.
+func T(lang, key string, args ...interface{}) string {
+	message, ok := catalogs[lang][key]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		message = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// IsSupported reports whether a language has a catalog of messages.
+//
+// Parameters:
+//   - lang: The code of the language, such as "en" or "id".
+//
+// Returns:
+//   - bool: True if the language is supported.
+func IsSupported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
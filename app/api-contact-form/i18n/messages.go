@@ -0,0 +1,316 @@
+// Package i18n provides localized messages for the API Contact Form application.
+//
+// This file defines the message keys and the catalog of messages for each supported language.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package i18n
+
+// Message keys of the catalogs.
+const (
+	MsgAPIRunning               = "api.running"
+	MsgHealthOK                 = "health.ok"
+	MsgContactCreated           = "contact.created"
+	MsgContactsRetrieved        = "contacts.retrieved"
+	MsgContactRetrieved         = "contact.retrieved"
+	MsgContactUpdated           = "contact.updated"
+	MsgContactDeleted           = "contact.deleted"
+	MsgContactPurged            = "contact.purged"
+	MsgTrashRetrieved           = "trash.retrieved"
+	MsgContactRestored          = "contact.restored"
+	MsgContactNotFound          = "contact.not_found"
+	MsgTrashedNotFound          = "trash.not_found"
+	MsgInvalidID                = "error.invalid_id"
+	MsgInvalidPermanent         = "error.invalid_permanent"
+	MsgInvalidCursor            = "error.invalid_cursor"
+	MsgInvalidQuery             = "error.invalid_query"
+	MsgMalformedJSON            = "error.malformed_json"
+	MsgValidationFailed         = "error.validation_failed"
+	MsgFieldType                = "error.field_type"
+	MsgNotFound                 = "error.not_found"
+	MsgConflict                 = "error.conflict"
+	MsgVersionMismatch          = "error.version_mismatch"
+	MsgIfMatchRequired          = "error.if_match_required"
+	MsgPreconditionFailed       = "error.precondition_failed"
+	MsgDataConflict             = "error.data_conflict"
+	MsgInvalidStatusTransition  = "error.invalid_status_transition"
+	MsgInvalidActor             = "error.invalid_actor"
+	MsgContactTransitioned      = "contact.transitioned"
+	MsgTransitionsRetrieved     = "transitions.retrieved"
+	MsgContactAssigned          = "contact.assigned"
+	MsgContactUnassigned        = "contact.unassigned"
+	MsgAssignmentsRetrieved     = "assignments.retrieved"
+	MsgActorRequired            = "error.actor_required"
+	MsgTagCreated               = "tag.created"
+	MsgTagsRetrieved            = "tags.retrieved"
+	MsgTagRetrieved             = "tag.retrieved"
+	MsgTagUpdated               = "tag.updated"
+	MsgTagDeleted               = "tag.deleted"
+	MsgTagNotFound              = "tag.not_found"
+	MsgTagNameTaken             = "tag.name_taken"
+	MsgContactTagsUpdated       = "contact.tags_updated"
+	MsgContactsTagged           = "contacts.tagged"
+	MsgNoteCreated              = "note.created"
+	MsgNotesRetrieved           = "notes.retrieved"
+	MsgNoteUpdated              = "note.updated"
+	MsgNoteDeleted              = "note.deleted"
+	MsgNoteNotFound             = "note.not_found"
+	MsgNoteRevisionsRetrieved   = "note_revisions.retrieved"
+	MsgReplySent                = "reply.sent"
+	MsgThreadRetrieved          = "thread.retrieved"
+	MsgMailUnavailable          = "error.mail_unavailable"
+	MsgMailDeliveryFailed       = "error.mail_delivery_failed"
+	MsgAttachmentNotFound       = "attachment.not_found"
+	MsgTooManyAttachments       = "error.too_many_attachments"
+	MsgAttachmentTooLarge       = "error.attachment_too_large"
+	MsgAttachmentTypeNotAllowed = "error.attachment_type_not_allowed"
+	MsgInvalidMultipartForm     = "error.invalid_multipart_form"
+	MsgRequestTooLarge          = "error.request_too_large"
+	MsgUnsupportedMediaType     = "error.unsupported_media_type"
+	MsgScanUnavailable          = "error.scan_unavailable"
+	MsgAttachmentQuarantined    = "error.attachment_quarantined"
+	MsgFormCreated              = "form.created"
+	MsgFormsRetrieved           = "forms.retrieved"
+	MsgFormRetrieved            = "form.retrieved"
+	MsgFormUpdated              = "form.updated"
+	MsgFormDeleted              = "form.deleted"
+	MsgFormSubmitted            = "form.submitted"
+	MsgFormNotFound             = "form.not_found"
+	MsgFormSlugTaken            = "form.slug_taken"
+	MsgFormReadOnly             = "form.read_only"
+	MsgFormInUse                = "form.in_use"
+	MsgFormUndeletable          = "form.undeletable"
+	MsgFormConfigRetrieved      = "form_config.retrieved"
+	MsgFormSubmitLabel          = "form_config.submit"
+	MsgFormOptionalLabel        = "form_config.optional"
+	MsgFormSuccessText          = "form_config.success"
+	MsgFormFailureText          = "form_config.failure"
+	MsgFormLanguageInvalid      = "error.form_language_invalid"
+	MsgFormThankYouURLInvalid   = "error.form_thank_you_url_invalid"
+	MsgFormSlugInvalid          = "error.form_slug_invalid"
+	MsgFormFieldNameInvalid     = "error.form_field_name_invalid"
+	MsgFormFieldDuplicate       = "error.form_field_duplicate"
+	MsgFormFieldNotText         = "error.form_field_not_text"
+	MsgFormEmailField           = "error.form_email_field"
+	MsgFormOptionsRequired      = "error.form_options_required"
+	MsgFormPatternInvalid       = "error.form_pattern_invalid"
+	MsgFieldRequired            = "error.field_required"
+	MsgFieldTooLong             = "error.field_too_long"
+	MsgFieldInvalidEmail        = "error.field_invalid_email"
+	MsgFieldInvalidURL          = "error.field_invalid_url"
+	MsgFieldInvalidOption       = "error.field_invalid_option"
+	MsgFieldPatternMismatch     = "error.field_pattern_mismatch"
+	MsgFieldUnknown             = "error.field_unknown"
+	MsgIdempotencyKeyInvalid    = "error.idempotency_key_invalid"
+	MsgIdempotencyKeyMismatch   = "error.idempotency_key_mismatch"
+	MsgIdempotencyKeyInProgress = "error.idempotency_key_in_progress"
+	MsgDatabaseTimeout          = "error.database_timeout"
+	MsgRequestCancelled         = "error.request_cancelled"
+	MsgInternalServerError      = "error.internal"
+)
+
+// catalogs maps each supported language to its messages by key.
+var catalogs = map[string]map[string]string{
+	English: {
+		MsgAPIRunning:               "API Contact Form is running.",
+		MsgHealthOK:                 "API is running.",
+		MsgContactCreated:           "Contact created successfully",
+		MsgContactsRetrieved:        "Contacts retrieved successfully",
+		MsgContactRetrieved:         "Contact retrieved successfully",
+		MsgContactUpdated:           "Contact updated successfully",
+		MsgContactDeleted:           "Contact deleted successfully",
+		MsgContactPurged:            "Contact permanently deleted successfully",
+		MsgTrashRetrieved:           "Deleted contacts retrieved successfully",
+		MsgContactRestored:          "Contact restored successfully",
+		MsgContactNotFound:          "Contact not found",
+		MsgTrashedNotFound:          "Contact not found in trash",
+		MsgInvalidID:                "Invalid ID",
+		MsgInvalidPermanent:         "Invalid permanent flag",
+		MsgInvalidCursor:            "Invalid cursor",
+		MsgInvalidQuery:             "Invalid query parameters",
+		MsgMalformedJSON:            "Malformed JSON payload",
+		MsgValidationFailed:         "Validation failed",
+		MsgFieldType:                "%s must be a %s",
+		MsgNotFound:                 "Resource not found",
+		MsgConflict:                 "The request conflicts with the current state",
+		MsgVersionMismatch:          "The contact has been changed by someone else. Reload it and try again",
+		MsgIfMatchRequired:          "The If-Match header is required",
+		MsgPreconditionFailed:       "The resource has been changed since it was read",
+		MsgDataConflict:             "The change conflicts with existing data",
+		MsgInvalidStatusTransition:  "A contact cannot move from status %s to %s",
+		MsgInvalidActor:             "The X-Actor header must be at most 100 characters long",
+		MsgContactTransitioned:      "Contact status changed successfully",
+		MsgTransitionsRetrieved:     "Status history retrieved successfully",
+		MsgContactAssigned:          "Contact assigned successfully",
+		MsgContactUnassigned:        "Contact unassigned successfully",
+		MsgAssignmentsRetrieved:     "Assignment history retrieved successfully",
+		MsgActorRequired:            "The X-Actor header is required",
+		MsgTagCreated:               "Tag created successfully",
+		MsgTagsRetrieved:            "Tags retrieved successfully",
+		MsgTagRetrieved:             "Tag retrieved successfully",
+		MsgTagUpdated:               "Tag updated successfully",
+		MsgTagDeleted:               "Tag deleted successfully",
+		MsgTagNotFound:              "Tag not found",
+		MsgTagNameTaken:             "A tag with this name already exists",
+		MsgContactTagsUpdated:       "Contact tags updated successfully",
+		MsgContactsTagged:           "Contacts tagged successfully",
+		MsgNoteCreated:              "Note created successfully",
+		MsgNotesRetrieved:           "Notes retrieved successfully",
+		MsgNoteUpdated:              "Note updated successfully",
+		MsgNoteDeleted:              "Note deleted successfully",
+		MsgNoteNotFound:             "Note not found",
+		MsgNoteRevisionsRetrieved:   "Note history retrieved successfully",
+		MsgReplySent:                "Reply sent successfully",
+		MsgThreadRetrieved:          "Conversation retrieved successfully",
+		MsgMailUnavailable:          "Sending emails is not configured",
+		MsgMailDeliveryFailed:       "The mail server did not accept the email. Try again later",
+		MsgAttachmentNotFound:       "Attachment not found",
+		MsgTooManyAttachments:       "At most %d files can be attached",
+		MsgAttachmentTooLarge:       "%s is larger than the maximum size of %s",
+		MsgAttachmentTypeNotAllowed: "%s is not an allowed file type",
+		MsgInvalidMultipartForm:     "The multipart form is malformed",
+		MsgRequestTooLarge:          "The request is too large",
+		MsgUnsupportedMediaType:     "The Content-Type must be application/merge-patch+json or application/json",
+		MsgScanUnavailable:          "%s could not be checked for malware, please try again later",
+		MsgAttachmentQuarantined:    "The attachment contains malware and is quarantined",
+		MsgFormCreated:              "Form created successfully",
+		MsgFormsRetrieved:           "Forms retrieved successfully",
+		MsgFormRetrieved:            "Form retrieved successfully",
+		MsgFormUpdated:              "Form updated successfully",
+		MsgFormDeleted:              "Form deleted successfully",
+		MsgFormSubmitted:            "Form submitted successfully",
+		MsgFormNotFound:             "Form not found",
+		MsgFormSlugTaken:            "A form with this slug already exists",
+		MsgFormReadOnly:             "Only the texts and thank-you URL of the default contact form can be changed",
+		MsgFormInUse:                "Contacts were submitted through the form, so it cannot be deleted",
+		MsgFormUndeletable:          "The default contact form cannot be deleted",
+		MsgFormConfigRetrieved:      "Form configuration retrieved successfully",
+		MsgFormSubmitLabel:          "Submit",
+		MsgFormOptionalLabel:        "Optional",
+		MsgFormSuccessText:          "Thank you! Your submission has been received.",
+		MsgFormFailureText:          "Your submission could not be sent. Please try again.",
+		MsgFormLanguageInvalid:      "%s is not a supported language",
+		MsgFormThankYouURLInvalid:   "%s must be an absolute http or https URL or a path starting with /",
+		MsgFormSlugInvalid:          "%s must contain only lowercase letters, numbers and single hyphens",
+		MsgFormFieldNameInvalid:     "%s must start with a lowercase letter and contain only lowercase letters, numbers and underscores",
+		MsgFormFieldDuplicate:       "%s is already used by another field",
+		MsgFormFieldNotText:         "%s must be a text type, because the field fills a column of the contact",
+		MsgFormEmailField:           "%s must include a required field named email of type email",
+		MsgFormOptionsRequired:      "%s must list the options of the field",
+		MsgFormPatternInvalid:       "%s is not a valid regular expression",
+		MsgFieldRequired:            "%s is a required field",
+		MsgFieldTooLong:             "%s must be a maximum of %d characters in length",
+		MsgFieldInvalidEmail:        "%s must be a valid email address",
+		MsgFieldInvalidURL:          "%s must be a valid URL",
+		MsgFieldInvalidOption:       "%s must be one of [%s]",
+		MsgFieldPatternMismatch:     "%s does not match the required format",
+		MsgFieldUnknown:             "%s is not a field of the form",
+		MsgIdempotencyKeyInvalid:    "The Idempotency-Key header must be at most 255 characters long",
+		MsgIdempotencyKeyMismatch:   "The Idempotency-Key was already used for a different request",
+		MsgIdempotencyKeyInProgress: "A request with the same Idempotency-Key is still being processed",
+		MsgDatabaseTimeout:          "The database did not respond in time",
+		MsgRequestCancelled:         "The request was cancelled",
+		MsgInternalServerError:      "An unexpected error occurred",
+	},
+	Indonesian: {
+		MsgAPIRunning:               "API Contact Form sedang berjalan.",
+		MsgHealthOK:                 "API sedang berjalan.",
+		MsgContactCreated:           "Kontak berhasil dibuat",
+		MsgContactsRetrieved:        "Daftar kontak berhasil diambil",
+		MsgContactRetrieved:         "Kontak berhasil diambil",
+		MsgContactUpdated:           "Kontak berhasil diperbarui",
+		MsgContactDeleted:           "Kontak berhasil dihapus",
+		MsgContactPurged:            "Kontak berhasil dihapus permanen",
+		MsgTrashRetrieved:           "Daftar kontak yang dihapus berhasil diambil",
+		MsgContactRestored:          "Kontak berhasil dipulihkan",
+		MsgContactNotFound:          "Kontak tidak ditemukan",
+		MsgTrashedNotFound:          "Kontak tidak ditemukan di tempat sampah",
+		MsgInvalidID:                "ID tidak valid",
+		MsgInvalidPermanent:         "Nilai permanent tidak valid",
+		MsgInvalidCursor:            "Kursor tidak valid",
+		MsgInvalidQuery:             "Parameter kueri tidak valid",
+		MsgMalformedJSON:            "Format JSON tidak valid",
+		MsgValidationFailed:         "Validasi gagal",
+		MsgFieldType:                "%s harus bertipe %s",
+		MsgNotFound:                 "Data tidak ditemukan",
+		MsgConflict:                 "Permintaan bertentangan dengan kondisi data saat ini",
+		MsgVersionMismatch:          "Kontak telah diubah oleh orang lain. Muat ulang lalu coba lagi",
+		MsgIfMatchRequired:          "Header If-Match wajib diisi",
+		MsgPreconditionFailed:       "Data telah diubah sejak terakhir dibaca",
+		MsgDataConflict:             "Perubahan bertentangan dengan data yang sudah ada",
+		MsgInvalidStatusTransition:  "Status kontak tidak dapat diubah dari %s ke %s",
+		MsgInvalidActor:             "Header X-Actor maksimal 100 karakter",
+		MsgContactTransitioned:      "Status kontak berhasil diubah",
+		MsgTransitionsRetrieved:     "Riwayat status berhasil diambil",
+		MsgContactAssigned:          "Kontak berhasil ditugaskan",
+		MsgContactUnassigned:        "Penugasan kontak berhasil dihapus",
+		MsgAssignmentsRetrieved:     "Riwayat penugasan berhasil diambil",
+		MsgActorRequired:            "Header X-Actor wajib diisi",
+		MsgTagCreated:               "Tag berhasil dibuat",
+		MsgTagsRetrieved:            "Daftar tag berhasil diambil",
+		MsgTagRetrieved:             "Tag berhasil diambil",
+		MsgTagUpdated:               "Tag berhasil diperbarui",
+		MsgTagDeleted:               "Tag berhasil dihapus",
+		MsgTagNotFound:              "Tag tidak ditemukan",
+		MsgTagNameTaken:             "Tag dengan nama ini sudah ada",
+		MsgContactTagsUpdated:       "Tag kontak berhasil diperbarui",
+		MsgContactsTagged:           "Tag kontak berhasil diterapkan",
+		MsgNoteCreated:              "Catatan berhasil dibuat",
+		MsgNotesRetrieved:           "Daftar catatan berhasil diambil",
+		MsgNoteUpdated:              "Catatan berhasil diperbarui",
+		MsgNoteDeleted:              "Catatan berhasil dihapus",
+		MsgNoteNotFound:             "Catatan tidak ditemukan",
+		MsgNoteRevisionsRetrieved:   "Riwayat catatan berhasil diambil",
+		MsgReplySent:                "Balasan berhasil dikirim",
+		MsgThreadRetrieved:          "Percakapan berhasil diambil",
+		MsgMailUnavailable:          "Pengiriman email belum dikonfigurasi",
+		MsgMailDeliveryFailed:       "Server email tidak menerima email. Coba lagi nanti",
+		MsgAttachmentNotFound:       "Lampiran tidak ditemukan",
+		MsgTooManyAttachments:       "Maksimal %d berkas dapat dilampirkan",
+		MsgAttachmentTooLarge:       "%s melebihi ukuran maksimal %s",
+		MsgAttachmentTypeNotAllowed: "Jenis berkas %s tidak diizinkan",
+		MsgInvalidMultipartForm:     "Format multipart form tidak valid",
+		MsgRequestTooLarge:          "Permintaan terlalu besar",
+		MsgUnsupportedMediaType:     "Content-Type harus application/merge-patch+json atau application/json",
+		MsgScanUnavailable:          "%s tidak dapat diperiksa dari malware, silakan coba lagi nanti",
+		MsgAttachmentQuarantined:    "Lampiran mengandung malware dan dikarantina",
+		MsgFormCreated:              "Formulir berhasil dibuat",
+		MsgFormsRetrieved:           "Daftar formulir berhasil diambil",
+		MsgFormRetrieved:            "Formulir berhasil diambil",
+		MsgFormUpdated:              "Formulir berhasil diperbarui",
+		MsgFormDeleted:              "Formulir berhasil dihapus",
+		MsgFormSubmitted:            "Formulir berhasil dikirim",
+		MsgFormNotFound:             "Formulir tidak ditemukan",
+		MsgFormSlugTaken:            "Formulir dengan slug ini sudah ada",
+		MsgFormReadOnly:             "Hanya teks dan URL terima kasih formulir kontak bawaan yang dapat diubah",
+		MsgFormInUse:                "Formulir tidak dapat dihapus karena sudah ada kontak yang dikirim melaluinya",
+		MsgFormUndeletable:          "Formulir kontak bawaan tidak dapat dihapus",
+		MsgFormConfigRetrieved:      "Konfigurasi formulir berhasil diambil",
+		MsgFormSubmitLabel:          "Kirim",
+		MsgFormOptionalLabel:        "Opsional",
+		MsgFormSuccessText:          "Terima kasih! Kiriman Anda telah kami terima.",
+		MsgFormFailureText:          "Kiriman Anda tidak dapat dikirim. Silakan coba lagi.",
+		MsgFormLanguageInvalid:      "%s bukan bahasa yang didukung",
+		MsgFormThankYouURLInvalid:   "%s harus berupa URL http atau https lengkap atau path yang diawali /",
+		MsgFormSlugInvalid:          "%s hanya boleh berisi huruf kecil, angka, dan tanda hubung tunggal",
+		MsgFormFieldNameInvalid:     "%s harus diawali huruf kecil dan hanya boleh berisi huruf kecil, angka, dan garis bawah",
+		MsgFormFieldDuplicate:       "%s sudah digunakan oleh kolom lain",
+		MsgFormFieldNotText:         "%s harus bertipe teks, karena kolom ini mengisi data kontak",
+		MsgFormEmailField:           "%s harus memiliki kolom wajib bernama email dengan tipe email",
+		MsgFormOptionsRequired:      "%s harus berisi pilihan untuk kolom ini",
+		MsgFormPatternInvalid:       "%s bukan ekspresi reguler yang valid",
+		MsgFieldRequired:            "%s wajib diisi",
+		MsgFieldTooLong:             "panjang %s maksimal %d karakter",
+		MsgFieldInvalidEmail:        "%s harus berupa alamat email yang valid",
+		MsgFieldInvalidURL:          "%s harus berupa URL yang valid",
+		MsgFieldInvalidOption:       "%s harus berupa salah satu dari [%s]",
+		MsgFieldPatternMismatch:     "format %s tidak sesuai",
+		MsgFieldUnknown:             "%s bukan kolom pada formulir ini",
+		MsgIdempotencyKeyInvalid:    "Header Idempotency-Key maksimal 255 karakter",
+		MsgIdempotencyKeyMismatch:   "Idempotency-Key sudah digunakan untuk permintaan yang berbeda",
+		MsgIdempotencyKeyInProgress: "Permintaan dengan Idempotency-Key yang sama masih diproses",
+		MsgDatabaseTimeout:          "Database tidak merespons tepat waktu",
+		MsgRequestCancelled:         "Permintaan dibatalkan",
+		MsgInternalServerError:      "Terjadi kesalahan yang tidak terduga",
+	},
+}
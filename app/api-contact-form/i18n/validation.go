@@ -0,0 +1,65 @@
+// Package i18n provides localized messages for the API Contact Form application.
+//
+// This file registers the validator translations of every supported language and translates
+// validation errors into the language of a request.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	idtranslations "github.com/go-playground/validator/v10/translations/id"
+)
+
+// universal holds the validator translators of the supported languages.
+var universal = ut.New(en.New(), en.New(), id.New())
+
+// RegisterValidationTranslations registers the translations of every supported language
+// with a validator, so that its errors can be passed to TranslateFieldError.
+//
+// The translations can be registered with a single validator only, so the same validator
+// must be shared by everything that validates requests.
+//
+// Parameters:
+//   - v: The validator to register the translations with.
+//
+// Returns:
+//   - error: An error if the translations could not be registered.
+func RegisterValidationTranslations(v *validator.Validate) error {
+	registrations := map[string]func(*validator.Validate, ut.Translator) error{
+		English:    entranslations.RegisterDefaultTranslations,
+		Indonesian: idtranslations.RegisterDefaultTranslations,
+	}
+
+	for lang, register := range registrations {
+		trans, _ := universal.GetTranslator(lang)
+		if err := register(v, trans); err != nil {
+			return fmt.Errorf("failed to register %s validation translations: %w", lang, err)
+		}
+	}
+
+	return nil
+}
+
+// TranslateFieldError returns the message of a validation error in the given language.
+//
+// Parameters:
+//   - lang: The code of the language, such as "en" or "id".
+//   - fe: The field error reported by the validator.
+//
+// Returns:
+//   - string: The localized message, or the English message if the language is not supported.
+func TranslateFieldError(lang string, fe validator.FieldError) string {
+	trans, found := universal.GetTranslator(lang)
+	if !found {
+		trans, _ = universal.GetTranslator(DefaultLanguage)
+	}
+	return fe.Translate(trans)
+}
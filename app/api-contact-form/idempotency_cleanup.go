@@ -0,0 +1,33 @@
+// Package main serves as the entry point for the API Contact Form application.
+//
+// This file provides the background job that removes expired idempotency keys.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package main
+
+import (
+	"api-contact-form/services"
+	"context"
+	"log"
+	"time"
+)
+
+// purgeExpiredIdempotencyKeys removes expired idempotency keys every interval, for as long
+// as the application runs. It is meant to be started in its own goroutine, and interval must
+// be positive.
+func purgeExpiredIdempotencyKeys(service services.IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		removed, err := service.PurgeExpired(context.Background())
+		if err != nil {
+			log.Printf("Failed to purge expired idempotency keys: %v", err)
+			continue
+		}
+		if removed > 0 {
+			log.Printf("Purged %d expired idempotency keys", removed)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Package inbound receives the emails sent to the contact mailbox of the API Contact Form
+// application.
+//
+// It parses MIME messages into Email values, converts HTML bodies to plain text, checks
+// senders against allow and deny rules, and runs an SMTP listener that hands every accepted
+// email to a Handler.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package inbound
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxPartDepth bounds how deeply multipart bodies may be nested.
+const maxPartDepth = 10
+
+// ErrMalformedEmail indicates that an email could not be parsed.
+var ErrMalformedEmail = errors.New("malformed email")
+
+// messageIDPattern matches a Message-ID, including angle brackets.
+var messageIDPattern = regexp.MustCompile(`<[^<>\s]+>`)
+
+// Email is a parsed inbound email.
+type Email struct {
+	// MessageID is the Message-ID header of the email, including angle brackets. It is empty
+	// when the header is missing.
+	MessageID string
+	// InReplyTo is the Message-ID of the email this one replies to, if any.
+	InReplyTo string
+	// References lists the Message-IDs of the earlier emails of the conversation, oldest first.
+	References []string
+	// From is the sender named in the From header.
+	From *mail.Address
+	// Recipients lists the envelope recipients the email was delivered to.
+	Recipients []string
+	// Subject is the decoded subject of the email.
+	Subject string
+	// Date is the Date header of the email, or the time it was received when the header is
+	// missing or invalid.
+	Date time.Time
+	// Text is the plain-text body of the email. HTML-only emails are converted to text.
+	Text string
+	// Attachments lists the files attached to the email.
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to an inbound email.
+type Attachment struct {
+	// Filename is the name of the file as given by the sender. It may be empty.
+	Filename string
+	// ContentType is the media type declared by the sender, such as "image/png".
+	ContentType string
+	// Data is the decoded content of the file.
+	Data []byte
+}
+
+// ParseEmail parses a MIME message.
+// Text parts are converted to UTF-8; when an email has both a plain-text and an HTML body,
+// the plain-text one is kept. Non-text parts and parts sent as attachments are returned
+// as attachments.
+// It returns an error wrapping ErrMalformedEmail if the message cannot be parsed.
+func ParseEmail(r io.Reader) (*Email, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedEmail, err)
+	}
+
+	decoder := &mime.WordDecoder{CharsetReader: charsetReader}
+	addresses := &mail.AddressParser{WordDecoder: decoder}
+	email := &Email{
+		MessageID:  firstMessageID(msg.Header.Get("Message-ID")),
+		InReplyTo:  firstMessageID(msg.Header.Get("In-Reply-To")),
+		References: messageIDPattern.FindAllString(msg.Header.Get("References"), -1),
+		Date:       time.Now(),
+	}
+	if from, err := addresses.Parse(msg.Header.Get("From")); err == nil {
+		email.From = from
+	}
+	if subject, err := decoder.DecodeHeader(msg.Header.Get("Subject")); err == nil {
+		email.Subject = strings.Join(strings.Fields(subject), " ")
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		email.Date = date
+	}
+
+	// Walk the body, keeping plain-text and HTML parts apart.
+	var body parsedBody
+	if err := body.walk(msg.Header, msg.Body, 0); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedEmail, err)
+	}
+	email.Attachments = body.attachments
+	if len(body.plain) > 0 {
+		email.Text = strings.TrimSpace(strings.Join(body.plain, "\n\n"))
+	} else {
+		email.Text = HTMLToText(strings.Join(body.html, "\n"))
+	}
+	return email, nil
+}
+
+// parsedBody collects the parts of an email body.
+type parsedBody struct {
+	plain       []string
+	html        []string
+	attachments []Attachment
+}
+
+// partHeader gives access to the MIME headers of a message or of one of its parts.
+type partHeader interface {
+	Get(key string) string
+}
+
+// walk collects a part of the email body, descending into multipart parts.
+func (b *parsedBody) walk(header partHeader, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return errors.New("too many nested parts")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	// Descend into multipart parts.
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := b.walk(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	// Keep inline text parts as the body and anything else as an attachment.
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if isText && disposition != "attachment" && filename == "" {
+		text, err := decodeCharset(params["charset"], data)
+		if err != nil {
+			return err
+		}
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+		if mediaType == "text/html" {
+			b.html = append(b.html, text)
+		} else {
+			b.plain = append(b.plain, text)
+		}
+		return nil
+	}
+	b.attachments = append(b.attachments, Attachment{
+		Filename:    filename,
+		ContentType: mediaType,
+		Data:        data,
+	})
+	return nil
+}
+
+// decodeTransfer returns a reader that decodes the content transfer encoding of a part.
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// decodeCharset converts text in the given charset to UTF-8. Text without a charset, or in
+// an unknown one, is kept as is.
+func decodeCharset(charset string, data []byte) (string, error) {
+	reader, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return string(data), nil
+	}
+	text, err := io.ReadAll(reader)
+	return string(text), err
+}
+
+// charsetReader returns a reader that converts text in the given charset to UTF-8.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	charset = strings.ToLower(strings.TrimSpace(charset))
+	if charset == "" || charset == "utf-8" || charset == "us-ascii" {
+		return input, nil
+	}
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, err
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+// firstMessageID returns the first Message-ID found in a header value, or an empty string.
+func firstMessageID(value string) string {
+	return messageIDPattern.FindString(value)
+}
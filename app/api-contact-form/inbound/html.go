@@ -0,0 +1,174 @@
+// Package inbound receives the emails sent to the contact mailbox of the API Contact Form
+// application.
+//
+// This file converts HTML email bodies to plain text.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package inbound
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blankLinesPattern matches runs of blank lines.
+var blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+
+// HTMLToText converts an HTML document to plain text.
+// Scripts, styles and the document head are dropped, block elements start new lines, list
+// items are prefixed with "- ", and links keep their target after their text when it differs.
+func HTMLToText(document string) string {
+	var out strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(document))
+	skipDepth, preDepth := 0, 0
+	var links []linkStart
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return tidyText(out.String())
+
+		case html.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+			text := string(tokenizer.Text())
+			if preDepth == 0 {
+				text = collapseSpaces(text, out.String())
+			}
+			out.WriteString(text)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			tag := atom.Lookup(name)
+			switch {
+			case isSkipped(tag):
+				skipDepth++
+			case tag == atom.Pre:
+				preDepth++
+				newLine(&out)
+			case tag == atom.Br:
+				out.WriteString("\n")
+			case tag == atom.Li:
+				newLine(&out)
+				out.WriteString("- ")
+			case tag == atom.A:
+				links = append(links, linkStart{href: linkTarget(tokenizer, hasAttr), offset: out.Len()})
+			case isBlock(tag):
+				newLine(&out)
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := atom.Lookup(name)
+			switch {
+			case isSkipped(tag):
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			case tag == atom.Pre:
+				if preDepth > 0 {
+					preDepth--
+				}
+				newLine(&out)
+			case tag == atom.A && len(links) > 0:
+				link := links[len(links)-1]
+				links = links[:len(links)-1]
+				text := strings.TrimSpace(out.String()[link.offset:])
+				if link.href != "" && text != link.href {
+					out.WriteString(" (" + link.href + ")")
+				}
+			case isBlock(tag):
+				newLine(&out)
+			}
+		}
+	}
+}
+
+// linkStart remembers where the text of a link starts in the output.
+type linkStart struct {
+	href   string
+	offset int
+}
+
+// linkTarget returns the href attribute of the current tag if it is a web or mail link.
+func linkTarget(tokenizer *html.Tokenizer, hasAttr bool) string {
+	for hasAttr {
+		var key, value []byte
+		key, value, hasAttr = tokenizer.TagAttr()
+		if string(key) != "href" {
+			continue
+		}
+		href := strings.TrimSpace(string(value))
+		lower := strings.ToLower(href)
+		if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "mailto:") {
+			return href
+		}
+		return ""
+	}
+	return ""
+}
+
+// isSkipped reports whether the content of an element is not part of the text.
+func isSkipped(tag atom.Atom) bool {
+	switch tag {
+	case atom.Head, atom.Script, atom.Style, atom.Title, atom.Noscript, atom.Template:
+		return true
+	}
+	return false
+}
+
+// isBlock reports whether an element starts and ends on its own line.
+func isBlock(tag atom.Atom) bool {
+	switch tag {
+	case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+		atom.Ul, atom.Ol, atom.Table, atom.Tr, atom.Blockquote, atom.Section,
+		atom.Article, atom.Header, atom.Footer, atom.Hr, atom.Address:
+		return true
+	}
+	return false
+}
+
+// newLine ends the current line of the output, unless it is already empty.
+func newLine(out *strings.Builder) {
+	if text := out.String(); text != "" && !strings.HasSuffix(text, "\n") {
+		out.WriteString("\n")
+	}
+}
+
+// collapseSpaces collapses the whitespace of HTML text into single spaces, dropping leading
+// whitespace at the start of a line.
+func collapseSpaces(text, written string) string {
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if collapsed == "" {
+		if text != "" && written != "" && !strings.HasSuffix(written, "\n") && !strings.HasSuffix(written, " ") {
+			return " "
+		}
+		return ""
+	}
+	if isSpace(text[0]) && written != "" && !strings.HasSuffix(written, "\n") && !strings.HasSuffix(written, " ") {
+		collapsed = " " + collapsed
+	}
+	if isSpace(text[len(text)-1]) {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+// isSpace reports whether a byte is HTML whitespace.
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f'
+}
+
+// tidyText trims the trailing spaces of every line and keeps at most one blank line in a row.
+func tidyText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
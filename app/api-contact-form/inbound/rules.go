@@ -0,0 +1,72 @@
+// Package inbound receives the emails sent to the contact mailbox of the API Contact Form
+// application.
+//
+// This file defines the SenderRules struct, which decides whose emails are accepted.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package inbound
+
+import (
+	"strings"
+)
+
+// SenderRules decides whose emails are accepted from allow and deny lists.
+// Each entry is either an email address, such as "jane@example.com", or a domain, such as
+// "example.com" or "@example.com", which also covers its subdomains.
+type SenderRules struct {
+	allow []string
+	deny  []string
+}
+
+// NewSenderRules creates SenderRules from the given allow and deny lists.
+// An empty allow list allows every sender that is not denied.
+func NewSenderRules(allow, deny []string) *SenderRules {
+	return &SenderRules{
+		allow: normalizeEntries(allow),
+		deny:  normalizeEntries(deny),
+	}
+}
+
+// Allows reports whether emails from the given address are accepted. Denied senders are
+// refused even if they are also allowed.
+func (r *SenderRules) Allows(address string) bool {
+	address = strings.ToLower(strings.TrimSpace(address))
+	at := strings.LastIndex(address, "@")
+	if at <= 0 || at == len(address)-1 {
+		return false
+	}
+	if matchesAny(r.deny, address) {
+		return false
+	}
+	return len(r.allow) == 0 || matchesAny(r.allow, address)
+}
+
+// matchesAny reports whether an address matches one of the entries.
+func matchesAny(entries []string, address string) bool {
+	domain := address[strings.LastIndex(address, "@")+1:]
+	for _, entry := range entries {
+		if strings.Contains(entry, "@") {
+			if entry == address {
+				return true
+			}
+			continue
+		}
+		if domain == entry || strings.HasSuffix(domain, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeEntries lowercases the entries and removes the "@" that may prefix domains.
+func normalizeEntries(entries []string) []string {
+	normalized := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(entry)), "@")
+		if entry != "" {
+			normalized = append(normalized, entry)
+		}
+	}
+	return normalized
+}
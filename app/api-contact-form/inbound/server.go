@@ -0,0 +1,179 @@
+// Package inbound receives the emails sent to the contact mailbox of the API Contact Form
+// application.
+//
+// This file runs the SMTP listener. It only accepts emails addressed to the configured
+// mailbox, including its plus-addressed variants, from senders allowed by the SenderRules,
+// and up to a maximum size. Every accepted email is parsed and handed to a Handler.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package inbound
+
+import (
+	"api-contact-form/mailer"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-smtp"
+)
+
+// ErrRejected indicates that an email will never be accepted, so the sending server should
+// not retry it. Handler errors that do not wrap ErrRejected are reported as temporary failures.
+var ErrRejected = errors.New("email rejected")
+
+// Handler processes the emails accepted by the SMTP listener.
+type Handler interface {
+	// HandleEmail processes an accepted email. The email is only acknowledged to the sending
+	// server if it returns nil.
+	HandleEmail(ctx context.Context, email *Email) error
+}
+
+// HandlerFunc adapts an ordinary function to the Handler interface.
+type HandlerFunc func(ctx context.Context, email *Email) error
+
+// HandleEmail calls f(ctx, email).
+func (f HandlerFunc) HandleEmail(ctx context.Context, email *Email) error {
+	return f(ctx, email)
+}
+
+// Config holds the settings of the SMTP listener.
+type Config struct {
+	// Addr is the TCP address to listen on, such as ":2525".
+	Addr string
+	// Domain is the host name the listener announces to clients.
+	Domain string
+	// Mailbox is the address emails are accepted for, such as "support@example.com".
+	// Plus-addressed variants, such as "support+tag@example.com", are accepted too.
+	Mailbox string
+	// MaxMessageBytes is the maximum size of an email, in bytes.
+	MaxMessageBytes int64
+	// MaxRecipients is the maximum number of recipients of an email.
+	MaxRecipients int
+	// Timeout bounds reading and writing each SMTP command, and the handling of each email.
+	Timeout time.Duration
+	// Rules decides whose emails are accepted.
+	Rules *SenderRules
+}
+
+// SMTP responses of the listener.
+var (
+	errSenderDenied = &smtp.SMTPError{
+		Code:         550,
+		EnhancedCode: smtp.EnhancedCode{5, 7, 1},
+		Message:      "Sender not allowed",
+	}
+	errUnknownMailbox = &smtp.SMTPError{
+		Code:         550,
+		EnhancedCode: smtp.EnhancedCode{5, 1, 1},
+		Message:      "No such mailbox",
+	}
+	errMalformedMessage = &smtp.SMTPError{
+		Code:         550,
+		EnhancedCode: smtp.EnhancedCode{5, 6, 0},
+		Message:      "Message could not be processed",
+	}
+	errTemporaryFailure = &smtp.SMTPError{
+		Code:         451,
+		EnhancedCode: smtp.EnhancedCode{4, 3, 0},
+		Message:      "Temporary failure, try again later",
+	}
+)
+
+// NewServer creates the SMTP listener that hands the accepted emails to the handler.
+// Call ListenAndServe on the returned server to start it.
+func NewServer(config Config, handler Handler) *smtp.Server {
+	backend := &backend{config: config, handler: handler}
+	server := smtp.NewServer(backend)
+	server.Addr = config.Addr
+	server.Domain = config.Domain
+	server.MaxMessageBytes = config.MaxMessageBytes
+	server.MaxRecipients = config.MaxRecipients
+	server.ReadTimeout = config.Timeout
+	server.WriteTimeout = config.Timeout
+	server.ErrorLog = log.Default()
+	return server
+}
+
+// backend creates a session for every SMTP connection.
+type backend struct {
+	config  Config
+	handler Handler
+}
+
+// NewSession starts the session of an SMTP connection.
+func (b *backend) NewSession(_ *smtp.Conn) (smtp.Session, error) {
+	return &session{backend: b}, nil
+}
+
+// session receives the emails of an SMTP connection.
+type session struct {
+	backend    *backend
+	recipients []string
+}
+
+// Mail refuses senders that are not allowed.
+func (s *session) Mail(from string, _ *smtp.MailOptions) error {
+	if !s.backend.config.Rules.Allows(from) {
+		return errSenderDenied
+	}
+	return nil
+}
+
+// Rcpt refuses recipients other than the mailbox and its plus-addressed variants.
+func (s *session) Rcpt(to string, _ *smtp.RcptOptions) error {
+	if base, _ := mailer.SplitPlusAddress(to); !strings.EqualFold(base, s.backend.config.Mailbox) {
+		return errUnknownMailbox
+	}
+	s.recipients = append(s.recipients, to)
+	return nil
+}
+
+// Data parses the email and hands it to the handler.
+func (s *session) Data(r io.Reader) error {
+	// Read the whole email. The reader fails once the maximum size is exceeded.
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	email, err := ParseEmail(bytes.NewReader(data))
+	if err != nil {
+		return errMalformedMessage
+	}
+
+	// The sender named in the message must be allowed too.
+	if email.From == nil || !s.backend.config.Rules.Allows(email.From.Address) {
+		return errSenderDenied
+	}
+	email.Recipients = s.recipients
+
+	ctx := context.Background()
+	if s.backend.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.backend.config.Timeout)
+		defer cancel()
+	}
+	if err := s.backend.handler.HandleEmail(ctx, email); err != nil {
+		if errors.Is(err, ErrRejected) {
+			return errMalformedMessage
+		}
+		log.Printf("Failed to handle email %s from %s: %v", email.MessageID, email.From.Address, err)
+		return errTemporaryFailure
+	}
+	return nil
+}
+
+// Reset discards the email being received.
+func (s *session) Reset() {
+	s.recipients = nil
+}
+
+// Logout ends the session.
+func (s *session) Logout() error {
+	return nil
+}
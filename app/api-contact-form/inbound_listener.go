@@ -0,0 +1,62 @@
+// Package main serves as the entry point for the API Contact Form application.
+//
+// This file starts the SMTP listener that receives the emails sent to the contact mailbox.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package main
+
+import (
+	"api-contact-form/config"
+	"api-contact-form/helpers"
+	"api-contact-form/inbound"
+	"api-contact-form/services"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/emersion/go-smtp"
+)
+
+// startInboundListener starts, in the background, the SMTP listener configured by the
+// environment, unless INBOUND_SMTP_ADDR is not set:
+//   - INBOUND_SMTP_ADDR is the address to listen on, such as ":2525".
+//   - INBOUND_MAILBOX is the address emails are accepted for. It is required.
+//   - INBOUND_SMTP_DOMAIN is the host name announced to clients. It defaults to "localhost".
+//   - INBOUND_MAX_MESSAGE_BYTES is the maximum size of an email. It defaults to 10 MiB.
+//   - INBOUND_SMTP_TIMEOUT bounds each SMTP command and the handling of each email. It defaults to 1m.
+//   - INBOUND_ALLOWED_SENDERS and INBOUND_DENIED_SENDERS list, comma-separated, the addresses
+//     and domains whose emails are accepted or refused.
+func startInboundListener(mailbox string, service services.InboundEmailService) {
+	addr := config.GetEnv("INBOUND_SMTP_ADDR", "")
+	if addr == "" {
+		return
+	}
+	if mailbox == "" {
+		log.Fatal("INBOUND_MAILBOX is required to receive emails")
+	}
+
+	server := inbound.NewServer(inbound.Config{
+		Addr:            addr,
+		Domain:          config.GetEnv("INBOUND_SMTP_DOMAIN", "localhost"),
+		Mailbox:         mailbox,
+		MaxMessageBytes: int64(config.GetEnvInt("INBOUND_MAX_MESSAGE_BYTES", 10<<20)),
+		MaxRecipients:   50,
+		Timeout:         config.GetEnvDuration("INBOUND_SMTP_TIMEOUT", time.Minute),
+		Rules:           inbound.NewSenderRules(helpers.ParseEnvList("INBOUND_ALLOWED_SENDERS"), helpers.ParseEnvList("INBOUND_DENIED_SENDERS")),
+	}, inbound.HandlerFunc(func(ctx context.Context, email *inbound.Email) error {
+		_, err := service.ReceiveEmail(ctx, email)
+		if errors.Is(err, services.ErrUnprocessableEmail) || errors.Is(err, services.ErrValidation) {
+			return errors.Join(inbound.ErrRejected, err)
+		}
+		return err
+	}))
+
+	go func() {
+		log.Printf("Receiving emails for %s on %s", mailbox, addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, smtp.ErrServerClosed) {
+			log.Fatalf("Failed to run the SMTP listener: %v", err)
+		}
+	}()
+}
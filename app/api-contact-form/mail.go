@@ -0,0 +1,49 @@
+// Package main serves as the entry point for the API Contact Form application.
+//
+// This file builds the mailer used to reply to contacts from the environment.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package main
+
+import (
+	"api-contact-form/config"
+	"api-contact-form/mailer"
+	"log"
+	"net/mail"
+	"time"
+)
+
+// newMailer returns the SMTP mailer configured by the environment, or nil when SMTP_HOST is
+// not set, in which case replying to contacts is disabled:
+//   - SMTP_HOST and SMTP_PORT locate the SMTP server. The port defaults to 587.
+//   - SMTP_USERNAME and SMTP_PASSWORD enable PLAIN authentication.
+//   - SMTP_TLS is none, starttls (the default), or tls.
+//   - SMTP_FROM is the sender address, such as "Support <support@example.com>". It is required.
+//   - SMTP_TIMEOUT bounds the delivery of each email. It defaults to 30s.
+func newMailer() mailer.Mailer {
+	host := config.GetEnv("SMTP_HOST", "")
+	if host == "" {
+		log.Println("SMTP_HOST is not set; replying to contacts is disabled")
+		return nil
+	}
+
+	from, err := mail.ParseAddress(config.GetEnv("SMTP_FROM", ""))
+	if err != nil {
+		log.Fatalf("Invalid SMTP_FROM: %v", err)
+	}
+
+	sender, err := mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     host,
+		Port:     config.GetEnvInt("SMTP_PORT", 587),
+		Username: config.GetEnv("SMTP_USERNAME", ""),
+		Password: config.GetEnv("SMTP_PASSWORD", ""),
+		TLS:      config.GetEnv("SMTP_TLS", mailer.TLSStartTLS),
+		From:     from,
+		Timeout:  config.GetEnvDuration("SMTP_TIMEOUT", 30*time.Second),
+	})
+	if err != nil {
+		log.Fatalf("Invalid SMTP configuration: %v", err)
+	}
+	return sender
+}
@@ -0,0 +1,38 @@
+// Package mailer sends email messages for the API Contact Form application.
+//
+// This file provides helpers for plus addressing, where a tag is added to the local part of
+// an address, such as "support+tag@example.com", so that replies can be routed by the tag.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package mailer
+
+import (
+	"strings"
+)
+
+// PlusAddress returns the address with the tag added to its local part, such as
+// "support+tag@example.com" for "support@example.com".
+func PlusAddress(address, tag string) string {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return address
+	}
+	return address[:at] + "+" + tag + address[at:]
+}
+
+// SplitPlusAddress splits a plus address into the address without the tag and the tag,
+// such as "support@example.com" and "tag" for "support+tag@example.com". The tag is empty
+// when the address has none.
+func SplitPlusAddress(address string) (base string, tag string) {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return address, ""
+	}
+	local, domain := address[:at], address[at:]
+	plus := strings.Index(local, "+")
+	if plus < 0 {
+		return address, ""
+	}
+	return local[:plus] + domain, local[plus+1:]
+}
@@ -0,0 +1,114 @@
+// Package mailer sends email messages for the API Contact Form application.
+//
+// It defines the Message struct and the Mailer interface, builds RFC 5322 messages with the
+// threading headers (Message-ID, In-Reply-To, References) that keep replies in a single
+// conversation, and provides an SMTP implementation of Mailer.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Mailer sends email messages.
+type Mailer interface {
+	// From returns the address outgoing messages are sent from.
+	From() *mail.Address
+	// Send delivers a message to its recipients. Messages without a sender are sent from From.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Message represents a plain-text email message.
+type Message struct {
+	// From is the sender of the message. When nil, the Mailer's From address is used.
+	From *mail.Address
+	// To lists the recipients of the message.
+	To []*mail.Address
+	// ReplyTo is the address replies should be sent to, if it differs from From.
+	ReplyTo *mail.Address
+	// Subject is the subject of the message.
+	Subject string
+	// Body is the plain-text content of the message.
+	Body string
+	// MessageID is the unique identifier of the message, including angle brackets,
+	// such as "<abc@example.com>".
+	MessageID string
+	// InReplyTo is the Message-ID of the message this one replies to, if any.
+	InReplyTo string
+	// References lists the Message-IDs of the earlier messages of the conversation, oldest first.
+	References []string
+	// Date is the time the message is sent. When zero, the current time is used.
+	Date time.Time
+}
+
+// NewMessageID returns a new, globally unique Message-ID for the given domain, including
+// angle brackets, such as "<4f6e...@example.com>".
+func NewMessageID(domain string) string {
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+	return "<" + hex.EncodeToString(random) + "@" + domain + ">"
+}
+
+// Bytes returns the message encoded as an RFC 5322 message with CRLF line endings.
+// The body is UTF-8 text encoded as quoted-printable, and non-ASCII header values are
+// encoded as RFC 2047 encoded words.
+func (m *Message) Bytes() []byte {
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	recipients := make([]string, 0, len(m.To))
+	for _, to := range m.To {
+		recipients = append(recipients, to.String())
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", m.From.String())
+	writeHeader(&buf, "To", strings.Join(recipients, ", "))
+	if m.ReplyTo != nil {
+		writeHeader(&buf, "Reply-To", m.ReplyTo.String())
+	}
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", headerValue(m.Subject)))
+	writeHeader(&buf, "Date", date.Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", m.MessageID)
+	if m.InReplyTo != "" {
+		writeHeader(&buf, "In-Reply-To", m.InReplyTo)
+	}
+	if len(m.References) > 0 {
+		writeHeader(&buf, "References", strings.Join(m.References, " "))
+	}
+	writeHeader(&buf, "MIME-Version", "1.0")
+	writeHeader(&buf, "Content-Type", "text/plain; charset=utf-8")
+	writeHeader(&buf, "Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	_, _ = body.Write([]byte(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n")))
+	_ = body.Close()
+	return buf.Bytes()
+}
+
+// writeHeader writes a header field, dropping line breaks from its value so that the value
+// cannot inject other header fields.
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(headerValue(value))
+	buf.WriteString("\r\n")
+}
+
+// headerValue returns the value with every line break replaced by a space.
+func headerValue(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,165 @@
+// Package mailer sends email messages for the API Contact Form application.
+//
+// This file implements Mailer over SMTP using net/smtp, with optional STARTTLS or implicit
+// TLS and PLAIN authentication. Without TLS and authentication it works with local fake
+// SMTP servers such as Mailpit.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// TLS modes of SMTPConfig.
+const (
+	// TLSNone sends messages in plain text. It is meant for local fake SMTP servers.
+	TLSNone = "none"
+	// TLSStartTLS upgrades the connection with the STARTTLS command, which the server must support.
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects over TLS from the start, usually on port 465.
+	TLSImplicit = "tls"
+)
+
+// SMTPConfig holds the settings of the SMTP server used to send messages.
+type SMTPConfig struct {
+	// Host is the host name of the SMTP server.
+	Host string
+	// Port is the port of the SMTP server, such as 587.
+	Port int
+	// Username is the user name for PLAIN authentication. Authentication is skipped when empty.
+	Username string
+	// Password is the password for PLAIN authentication.
+	Password string
+	// TLS is one of TLSNone, TLSStartTLS, or TLSImplicit.
+	TLS string
+	// From is the address messages are sent from.
+	From *mail.Address
+	// Timeout bounds the whole delivery of a message. Zero disables the timeout.
+	Timeout time.Duration
+}
+
+// smtpMailer is the SMTP implementation of Mailer.
+type smtpMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer creates a new Mailer that sends messages through the configured SMTP server.
+// It returns an error if the TLS mode is unknown or the sender address is missing.
+func NewSMTPMailer(config SMTPConfig) (Mailer, error) {
+	switch config.TLS {
+	case TLSNone, TLSStartTLS, TLSImplicit:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q", config.TLS)
+	}
+	if config.From == nil {
+		return nil, errors.New("missing SMTP sender address")
+	}
+	return &smtpMailer{config}, nil
+}
+
+// From returns the address outgoing messages are sent from.
+func (m *smtpMailer) From() *mail.Address {
+	return m.config.From
+}
+
+// Send delivers a message through the SMTP server. The delivery is aborted when the context
+// is done or the configured timeout elapses.
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	if msg.From == nil {
+		msg.From = m.config.From
+	}
+	if m.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.Timeout)
+		defer cancel()
+	}
+
+	// Connect to the server, over TLS from the start if configured so.
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+	tlsConfig := &tls.Config{ServerName: m.config.Host}
+	var conn net.Conn
+	var err error
+	if m.config.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	// net/smtp does not take a context, so cancellation interrupts the connection instead.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if err := m.deliver(client, tlsConfig, msg); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("%w: %v", ctxErr, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// deliver runs the SMTP session that sends the message: STARTTLS and authentication when
+// configured, then the envelope, the data, and QUIT.
+func (m *smtpMailer) deliver(client *smtp.Client, tlsConfig *tls.Config, msg *Message) error {
+	if m.config.TLS == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if m.config.Username != "" {
+		auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate with SMTP server: %w", err)
+		}
+	}
+
+	if err := client.Mail(msg.From.Address); err != nil {
+		return fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to.Address); err != nil {
+			return fmt.Errorf("SMTP server rejected recipient %s: %w", to.Address, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP server rejected message data: %w", err)
+	}
+	if _, err := writer.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send message data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected message: %w", err)
+	}
+	return client.Quit()
+}
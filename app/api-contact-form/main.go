@@ -2,7 +2,8 @@
 //
 // It initializes the necessary configurations, sets up the database connection,
 // configures repositories, services, and handlers, and starts the HTTP server
-// using the Gin framework.
+// using the Gin framework. It also provides the "migrate" subcommand for managing
+// the database schema.
 //
 // Author: Tri Wicaksono
 // Website: https://triwicaksono.com
@@ -12,22 +13,34 @@ import (
 	"api-contact-form/config"
 	"api-contact-form/handlers"
 	"api-contact-form/helpers"
+	"api-contact-form/i18n"
+	"api-contact-form/middlewares"
+	"api-contact-form/migrations"
 	"api-contact-form/repositories"
 	"api-contact-form/services"
 	"log"
+	"os"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 )
 
+// maxFormSubmissionBytes is the maximum size of a submission of a form, which holds the
+// values of its fields as JSON.
+const maxFormSubmissionBytes = 1 << 20
+
 // main is the entry point of the application.
 // It performs the following steps:
 // 1. Loads environment variables from the .env file.
-// 2. Initializes the database connection.
-// 3. Sets up repositories, services, and handlers.
-// 4. Configures the Gin router with necessary middleware and routes.
-// 5. Starts the HTTP server on the specified port.
+// 2. Runs the "migrate" subcommand instead of the server when requested.
+// 3. Initializes the database connection and verifies that the schema is up to date.
+// 4. Configures the request validator and sets up repositories, services, and handlers.
+// 5. Configures the Gin router with necessary middleware and routes.
+// 6. Starts the HTTP server on the specified port.
 func main() {
 	// Load environment variables from the .env file.
 	err := godotenv.Load()
@@ -35,15 +48,69 @@ func main() {
 		log.Println("Error loading .env file")
 	}
 
+	// Run the migrate subcommand when requested.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(os.Args[2:]))
+	}
+
 	// Initialize the database connection.
 	config.InitDB()
 
+	// Refuse to start unless every migration has been applied.
+	migrator, err := migrations.New(config.DB)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	ensureSchemaUpToDate(migrator, helpers.GetEnvBool("DB_MIGRATE_ON_START", false))
+
+	// Configure the request validator shared by Gin and the services, so that validation
+	// errors name fields as they appear in requests and can be translated.
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		log.Fatal("Unsupported request validator engine")
+	}
+	validate.RegisterTagNameFunc(helpers.RequestFieldName)
+	if err := i18n.RegisterValidationTranslations(validate); err != nil {
+		log.Fatalf("Failed to register validation translations: %v", err)
+	}
+
 	// Initialize repositories, services, and handlers.
 	mainHandler := handlers.NewMainHandler()
 	healthHandler := handlers.NewHealthHandler()
-	contactRepository := repositories.NewContactRepository(config.DB)
-	contactService := services.NewContactService(contactRepository)
+	queryTimeout := config.GetEnvDuration("DB_QUERY_TIMEOUT", 10*time.Second)
+	contactRepository := repositories.NewContactRepository(config.DB, queryTimeout)
+	contactSearcher := repositories.NewContactSearcher(config.DB, queryTimeout)
+	attachments := newAttachments(newFileStore(), config.DB, queryTimeout)
+	contactService := services.NewContactService(contactRepository, contactSearcher, attachments, validate, assignmentRules(contactRepository)...)
 	contactHandler := handlers.NewContactHandler(contactService)
+	contactAttachmentService := services.NewContactAttachmentService(contactRepository, attachments)
+	contactAttachmentHandler := handlers.NewContactAttachmentHandler(contactAttachmentService)
+	tagRepository := repositories.NewTagRepository(config.DB, queryTimeout)
+	tagService := services.NewTagService(tagRepository, validate)
+	tagHandler := handlers.NewTagHandler(tagService)
+	formRepository := repositories.NewFormRepository(config.DB, queryTimeout)
+	formService := services.NewFormService(formRepository, contactService, validate)
+	formHandler := handlers.NewFormHandler(formService, config.GetEnvDuration("FORM_CONFIG_MAX_AGE", time.Minute))
+	contactNoteRepository := repositories.NewContactNoteRepository(config.DB, queryTimeout)
+	contactNoteService := services.NewContactNoteService(contactRepository, contactNoteRepository, validate)
+	contactNoteHandler := handlers.NewContactNoteHandler(contactNoteService)
+	contactThreadRepository := repositories.NewContactThreadRepository(config.DB, queryTimeout)
+	mailbox := config.GetEnv("INBOUND_MAILBOX", "")
+	contactReplyService := services.NewContactReplyService(contactRepository, contactThreadRepository, newMailer(), validate, config.GetEnv("MAIL_REPLY_SUBJECT", "Re: Your message"), mailbox)
+	contactReplyHandler := handlers.NewContactReplyHandler(contactReplyService)
+	inboundEmailService := services.NewInboundEmailService(contactService, contactThreadRepository, attachments, mailbox)
+	idempotencyRepository := repositories.NewIdempotencyRepository(config.DB, queryTimeout)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepository, config.GetEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour))
+
+	// Remove expired idempotency keys in the background, unless purging is disabled.
+	if interval := config.GetEnvDuration("IDEMPOTENCY_PURGE_INTERVAL", time.Hour); interval > 0 {
+		go purgeExpiredIdempotencyKeys(idempotencyService, interval)
+	} else {
+		log.Println("Purging of expired idempotency keys is disabled")
+	}
+
+	// Receive the emails sent to the contact mailbox in the background.
+	startInboundListener(mailbox, inboundEmailService)
 
 	// Create a new Gin router with default middleware (logger and recovery).
 	router := gin.Default()
@@ -61,14 +128,50 @@ func main() {
 	// Apply the CORS middleware to the router.
 	router.Use(cors.New(corsConfig))
 
+	// Negotiate the language of response messages for every request.
+	router.Use(middlewares.Language())
+
 	// Define application routes and associate them with their respective handlers.
 	router.GET("/", mainHandler.MainHandler)
 	router.GET("/health", healthHandler.HealthCheck)
 	router.GET("/contacts", contactHandler.GetContacts)
+	router.GET("/contacts/search", contactHandler.SearchContacts)
+	router.GET("/contacts/trash", contactHandler.GetTrashedContacts)
+	router.GET("/contacts/mine", contactHandler.GetMyContacts)
+	router.POST("/contacts/tags/bulk", contactHandler.BulkTagContacts)
 	router.GET("/contacts/:id", contactHandler.GetContact)
-	router.POST("/contacts", contactHandler.CreateContact)
+	router.POST("/contacts", middlewares.BodyLimit(maxContactBodyBytes(attachments.Limits())), middlewares.Idempotency(idempotencyService), contactHandler.CreateContact)
 	router.PUT("/contacts/:id", contactHandler.UpdateContact)
+	router.PATCH("/contacts/:id", contactHandler.PatchContact)
 	router.DELETE("/contacts/:id", contactHandler.DeleteContact)
+	router.POST("/contacts/:id/restore", contactHandler.RestoreContact)
+	router.POST("/contacts/:id/transitions", contactHandler.TransitionContact)
+	router.GET("/contacts/:id/transitions", contactHandler.GetContactTransitions)
+	router.POST("/contacts/:id/assign", contactHandler.AssignContact)
+	router.POST("/contacts/:id/unassign", contactHandler.UnassignContact)
+	router.GET("/contacts/:id/assignments", contactHandler.GetContactAssignments)
+	router.POST("/contacts/:id/tags", contactHandler.AddContactTags)
+	router.DELETE("/contacts/:id/tags/:tag_id", contactHandler.RemoveContactTag)
+	router.GET("/contacts/:id/notes", contactNoteHandler.GetNotes)
+	router.POST("/contacts/:id/notes", contactNoteHandler.CreateNote)
+	router.PUT("/contacts/:id/notes/:note_id", contactNoteHandler.UpdateNote)
+	router.DELETE("/contacts/:id/notes/:note_id", contactNoteHandler.DeleteNote)
+	router.GET("/contacts/:id/notes/:note_id/revisions", contactNoteHandler.GetNoteRevisions)
+	router.POST("/contacts/:id/replies", contactReplyHandler.SendReply)
+	router.GET("/contacts/:id/thread", contactReplyHandler.GetThread)
+	router.GET("/contacts/:id/attachments/:attachment_id", contactAttachmentHandler.DownloadAttachment)
+	router.GET("/tags", tagHandler.GetTags)
+	router.POST("/tags", tagHandler.CreateTag)
+	router.GET("/tags/:id", tagHandler.GetTag)
+	router.PUT("/tags/:id", tagHandler.UpdateTag)
+	router.DELETE("/tags/:id", tagHandler.DeleteTag)
+	router.GET("/forms", formHandler.GetForms)
+	router.POST("/forms", formHandler.CreateForm)
+	router.GET("/forms/:slug", formHandler.GetForm)
+	router.GET("/forms/:slug/config", formHandler.GetFormConfig)
+	router.PUT("/forms/:slug", formHandler.UpdateForm)
+	router.DELETE("/forms/:slug", formHandler.DeleteForm)
+	router.POST("/forms/:slug/submissions", middlewares.BodyLimit(maxFormSubmissionBytes), middlewares.Idempotency(idempotencyService), formHandler.SubmitForm)
 
 	// Retrieve the application port from environment variables with a default value of "8080".
 	appPort := config.GetEnv("APP_PORT", "8080")
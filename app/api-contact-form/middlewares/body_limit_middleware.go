@@ -0,0 +1,31 @@
+// Package middlewares contains the Gin middleware used by the API Contact Form application.
+//
+// The BodyLimit middleware caps the size of request bodies, so that large uploads are refused
+// before they are read into memory or written to disk.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package middlewares
+
+import (
+	"api-contact-form/i18n"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit returns a middleware that caps request bodies at maxBytes.
+//
+// Requests that announce a larger Content-Length are refused at once with a 413 status code.
+// Other bodies fail to be read past the limit, with an *http.MaxBytesError, which handlers
+// report with a 413 status code as well.
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			abortWithMessage(c, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", i18n.MsgRequestTooLarge)
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
@@ -0,0 +1,187 @@
+// Package middlewares contains the Gin middleware used by the API Contact Form application.
+//
+// The Idempotency middleware honors the Idempotency-Key request header, so that clients can
+// safely retry requests that create resources. The first request with a key is processed and
+// its response stored; retries with the same key and request replay the stored response.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package middlewares
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/responses"
+	"api-contact-form/services"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header holding the idempotency key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is the response header set to "true" on replayed responses.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength is the maximum length of an idempotency key.
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders lists the response headers stored with a response and replayed on retries.
+var replayedHeaders = []string{"Content-Type", "Content-Language", "ETag", "Location"}
+
+// responseRecorder is a gin.ResponseWriter that keeps a copy of the response body.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes the data to the response and keeps a copy of it.
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the string to the response and keeps a copy of it.
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency returns a middleware that honors the Idempotency-Key request header.
+//
+// Requests without the header are processed as usual. For a request with the header:
+//   - If the key is new, the request is processed and its response is stored with the key.
+//     Server errors (5xx) are not stored, so that the request can be retried with the same key.
+//   - If the key belongs to a completed request with the same method, path, and body, the stored
+//     response is replayed with the Idempotent-Replayed header set to "true".
+//   - If the key belongs to a different request, it responds with a 422 status code.
+//   - If the key belongs to a request still in progress, it responds with a 409 status code.
+func Idempotency(service services.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithMessage(c, http.StatusBadRequest, "BAD_REQUEST", i18n.MsgIdempotencyKeyInvalid)
+			return
+		}
+
+		// Read the body to hash it, then restore it for the handler.
+		body, err := io.ReadAll(c.Request.Body)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			abortWithMessage(c, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", i18n.MsgRequestTooLarge)
+			return
+		}
+		if err != nil {
+			abortWithMessage(c, http.StatusBadRequest, "BAD_REQUEST", i18n.MsgMalformedJSON)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Reserve the key, or find the stored response of a previous request with the key.
+		stored, err := service.Begin(c.Request.Context(), key, requestHash(c.Request, body))
+		switch {
+		case errors.Is(err, services.ErrIdempotencyKeyMismatch):
+			abortWithMessage(c, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_MISMATCH", i18n.MsgIdempotencyKeyMismatch)
+			return
+		case errors.Is(err, services.ErrIdempotencyKeyInProgress):
+			abortWithMessage(c, http.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS", i18n.MsgIdempotencyKeyInProgress)
+			return
+		case err != nil:
+			log.Printf("Failed to reserve idempotency key: %v", err)
+			abortWithMessage(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", i18n.MsgInternalServerError)
+			return
+		case stored != nil:
+			replay(c, stored)
+			return
+		}
+
+		// Process the request while recording its response. The outcome is saved even if
+		// the client goes away, so that its retry finds the stored response.
+		ctx := context.WithoutCancel(c.Request.Context())
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		release := true
+		defer func() {
+			if !release {
+				return
+			}
+			if err := service.Release(ctx, key); err != nil {
+				log.Printf("Failed to release idempotency key: %v", err)
+			}
+		}()
+
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		// The request was processed, so the key stays reserved even if its response cannot
+		// be stored: retries are then refused until the key expires, rather than processed again.
+		release = false
+
+		headers := make(map[string]string)
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+
+		err = service.Complete(ctx, key, &services.StoredResponse{
+			StatusCode: recorder.Status(),
+			Headers:    headers,
+			Body:       recorder.body.Bytes(),
+		})
+		if err != nil {
+			log.Printf("Failed to store idempotent response, keeping the key reserved until it expires: %v", err)
+		}
+	}
+}
+
+// requestHash returns the hex-encoded SHA-256 hash of the request method, path, and body.
+// The boundary of multipart bodies is left out, since clients pick a new one on every attempt.
+func requestHash(r *http.Request, body []byte) string {
+	if mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		body = bytes.ReplaceAll(body, []byte(params["boundary"]), nil)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replay writes a stored response and aborts the request.
+func replay(c *gin.Context, stored *services.StoredResponse) {
+	for name, value := range stored.Headers {
+		c.Header(name, value)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(stored.StatusCode)
+	c.Writer.Write(stored.Body)
+	c.Abort()
+}
+
+// abortWithMessage responds with an API response holding the catalog message with the
+// given key, in the language of the request, and aborts the request.
+func abortWithMessage(c *gin.Context, status int, code, key string) {
+	c.AbortWithStatusJSON(status, responses.APIResponse{
+		Code:    code,
+		Message: i18n.T(c.GetString(i18n.ContextKey), key),
+		Data:    nil,
+	})
+}
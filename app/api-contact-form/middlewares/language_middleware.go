@@ -0,0 +1,30 @@
+// Package middlewares contains the Gin middleware used by the API Contact Form application.
+//
+// The Language middleware negotiates the language of each request, so that handlers can
+// respond with messages and validation errors in the language the client asked for.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package middlewares
+
+import (
+	"api-contact-form/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language returns a middleware that negotiates the language of each request.
+//
+// The language is taken from the lang query parameter if present, and otherwise from the
+// Accept-Language header. The negotiated language is stored in the request context under
+// i18n.ContextKey and reported in the Content-Language response header.
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+
+		c.Set(i18n.ContextKey, lang)
+		c.Header("Content-Language", lang)
+
+		c.Next()
+	}
+}
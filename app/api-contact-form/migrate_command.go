@@ -0,0 +1,127 @@
+// Package main serves as the entry point for the API Contact Form application.
+//
+// This file implements the "migrate" subcommand, which applies, reverts, and reports
+// the versioned database migrations:
+//
+//	api-contact-form migrate up          Apply all pending migrations.
+//	api-contact-form migrate down [N]    Revert the last N applied migrations (default 1).
+//	api-contact-form migrate status      List every migration and whether it is applied.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package main
+
+import (
+	"api-contact-form/config"
+	"api-contact-form/migrations"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// migrateUsage describes the arguments accepted by the migrate subcommand.
+const migrateUsage = "usage: api-contact-form migrate up|down [N]|status"
+
+// runMigrateCommand executes the migrate subcommand with the given arguments and returns
+// the process exit code.
+func runMigrateCommand(args []string) int {
+	if len(args) == 0 {
+		log.Println(migrateUsage)
+		return 2
+	}
+
+	// Initialize the database connection and load the migrations.
+	config.InitDB()
+	migrator, err := migrations.New(config.DB)
+	if err != nil {
+		log.Printf("Failed to load migrations: %v", err)
+		return 1
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Printf("Migration failed: %v", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			log.Println("Database schema is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Println(migrateUsage)
+				return 2
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			log.Printf("Reverted migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Printf("Migration failed: %v", err)
+			return 1
+		}
+		if len(reverted) == 0 {
+			log.Println("No applied migrations to revert")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Printf("Failed to read migration status: %v", err)
+			return 1
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, status := range statuses {
+			state, appliedAt := "pending", ""
+			if status.Applied {
+				state, appliedAt = "applied", status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(writer, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		writer.Flush()
+	default:
+		log.Println(migrateUsage)
+		return 2
+	}
+
+	return 0
+}
+
+// ensureSchemaUpToDate verifies that every migration has been applied before the server starts.
+// When DB_MIGRATE_ON_START is enabled, pending migrations are applied first; the migration lock
+// makes this safe when several replicas start at the same time.
+// It terminates the process if the schema is behind.
+func ensureSchemaUpToDate(migrator *migrations.Migrator, migrateOnStart bool) {
+	ctx := context.Background()
+
+	if migrateOnStart {
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		log.Fatalf("Failed to read migration status: %v", err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("Database schema is behind by %d migration(s), starting with %04d_%s. Run \"api-contact-form migrate up\" first.",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+}
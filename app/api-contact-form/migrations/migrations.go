@@ -0,0 +1,382 @@
+// Package migrations manages the versioned database schema of the API Contact Form application.
+//
+// Migrations are plain SQL files embedded into the binary, with one directory per database
+// dialect (mysql, postgres, sqlite). Each migration consists of an "up" file and a "down" file
+// named NNNN_description.up.sql and NNNN_description.down.sql, where NNNN is the version.
+// Statements within a file are separated by a semicolon at the end of a line.
+//
+// Applied versions are recorded in the schema_migrations table. A database-level lock ensures
+// that only one instance migrates at a time when several replicas start together.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed mysql postgres sqlite
+var files embed.FS
+
+// lockName is the name of the MySQL/MariaDB named lock held while migrating.
+const lockName = "api_contact_form_schema_migrations"
+
+// lockKey is the PostgreSQL advisory lock key held while migrating.
+const lockKey int64 = 720_301_557_118
+
+// lockTimeout is how long to wait for another instance to finish migrating.
+const lockTimeout = 2 * time.Minute
+
+// fileNamePattern matches migration file names and captures the version, name, and direction.
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// statementSeparator splits a migration file into statements on semicolons at the end of a line.
+var statementSeparator = regexp.MustCompile(`;\s*(\n|$)`)
+
+// ErrLockTimeout is returned when the migration lock cannot be acquired in time.
+var ErrLockTimeout = errors.New("timed out waiting for the migration lock")
+
+// createTableStatements holds the dialect-specific DDL of the schema_migrations table.
+var createTableStatements = map[string]string{
+	"mysql": `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at DATETIME NOT NULL
+	)`,
+	"postgres": `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`,
+	"sqlite": `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at DATETIME NOT NULL
+	)`,
+}
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	// Version orders the migrations. It is unique within a dialect.
+	Version int64
+	// Name describes the migration.
+	Name string
+	// Up holds the SQL that applies the migration.
+	Up string
+	// Down holds the SQL that reverts the migration.
+	Down string
+}
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Migration
+	// Applied reports whether the migration has been applied.
+	Applied bool
+	// AppliedAt records when the migration was applied. It is zero for pending migrations.
+	AppliedAt time.Time
+}
+
+// Migrator applies and reverts the migrations of a single database.
+type Migrator struct {
+	db         *sql.DB
+	dialect    string
+	migrations []Migration
+}
+
+// New creates a Migrator for the database behind the provided GORM DB.
+// It loads the embedded migrations of the database's dialect and returns an error
+// if the dialect is not supported or the migration files are malformed.
+func New(db *gorm.DB) (*Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	dialect := db.Dialector.Name()
+	if _, ok := createTableStatements[dialect]; !ok {
+		return nil, fmt.Errorf("migrations are not available for the %q dialect", dialect)
+	}
+
+	migrations, err := load(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: sqlDB, dialect: dialect, migrations: migrations}, nil
+}
+
+// load reads the embedded migration files of a dialect, ordered by version.
+func load(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(files, path.Join(dialect, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies all pending migrations in version order while holding the migration lock.
+// It returns the migrations that were applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts up to steps of the most recently applied migrations while holding the migration lock.
+// It returns the migrations that were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status reports every known migration and whether it has been applied, in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, applied := versions[migration.Version]
+		statuses = append(statuses, Status{Migration: migration, Applied: applied, AppliedAt: appliedAt})
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations that have not been applied yet, in version order.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// withLock runs fn on a dedicated connection while holding the database-level migration lock.
+// SQLite databases are accessed by a single process and need no additional locking.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	switch m.dialect {
+	case "mysql":
+		var acquired sql.NullInt64
+		err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(lockTimeout.Seconds())).Scan(&acquired)
+		if err != nil {
+			return err
+		}
+		if !acquired.Valid || acquired.Int64 != 1 {
+			return ErrLockTimeout
+		}
+		defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+	case "postgres":
+		lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+		defer cancel()
+		if _, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return ErrLockTimeout
+			}
+			return err
+		}
+		defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions creates the schema_migrations table if needed and returns the applied
+// versions mapped to the time they were applied.
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	if _, err := conn.ExecContext(ctx, createTableStatements[m.dialect]); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = appliedAt
+	}
+	return versions, rows.Err()
+}
+
+// apply runs the up or down SQL of a migration and records the change in schema_migrations,
+// within a single transaction. Note that MySQL/MariaDB commit DDL statements implicitly, so a
+// failing migration there may leave earlier statements of the same file applied.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, up bool) error {
+	script, direction := migration.Down, "down"
+	if up {
+		script, direction = migration.Up, "up"
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range splitStatements(script) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("migration %d_%s %s failed: %w", migration.Version, migration.Name, direction, err)
+		}
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, m.rebind("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)"),
+			migration.Version, migration.Name, time.Now())
+	} else {
+		_, err = tx.ExecContext(ctx, m.rebind("DELETE FROM schema_migrations WHERE version = ?"), migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// rebind converts '?' placeholders into the numbered placeholders used by PostgreSQL.
+func (m *Migrator) rebind(query string) string {
+	if m.dialect != "postgres" {
+		return query
+	}
+	var builder strings.Builder
+	position := 0
+	for _, r := range query {
+		if r == '?' {
+			position++
+			builder.WriteString("$" + strconv.Itoa(position))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// splitStatements splits a migration script into its statements, dropping empty
+// statements and statements consisting only of comments.
+func splitStatements(script string) []string {
+	var statements []string
+	for _, statement := range statementSeparator.Split(script, -1) {
+		if hasSQL(statement) {
+			statements = append(statements, strings.TrimSpace(statement))
+		}
+	}
+	return statements
+}
+
+// hasSQL reports whether a statement contains anything besides whitespace and line comments.
+func hasSQL(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+-- Create the contact_messages table. IF NOT EXISTS keeps this migration safe on
+-- databases that were created by the former GORM AutoMigrate. Those already have the
+-- deleted_at index, so it is declared with the table, since MySQL lacks
+-- CREATE INDEX IF NOT EXISTS. The FULLTEXT index used by search is added by migration 0014,
+-- which checks whether the index exists first.
+CREATE TABLE IF NOT EXISTS contact_messages (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    full_name VARCHAR(100) NOT NULL,
+    email_address VARCHAR(100) NOT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    message_text TEXT NOT NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    deleted_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_contact_messages_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_messages_created_at ON contact_messages (created_at, id);
+
+-- Active contacts used to be marked with a zero date instead of NULL.
+UPDATE contact_messages SET deleted_at = NULL WHERE deleted_at < '1000-01-01';
@@ -0,0 +1 @@
+ALTER TABLE contact_messages DROP COLUMN version;
@@ -0,0 +1,14 @@
+-- Idempotency keys let clients retry POST requests without creating duplicates.
+-- A key whose status_code is NULL belongs to a request that is still in progress.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INT NULL,
+    response_headers TEXT NULL,
+    response_body MEDIUMTEXT NULL,
+    created_at DATETIME(3) NULL,
+    expires_at DATETIME(3) NOT NULL,
+    PRIMARY KEY (idempotency_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS contact_status_transitions;
+
+DROP INDEX idx_contact_messages_status ON contact_messages;
+
+ALTER TABLE contact_messages DROP COLUMN status;
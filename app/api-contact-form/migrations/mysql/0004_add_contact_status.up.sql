@@ -0,0 +1,18 @@
+-- The status tracks where a contact is in its handling workflow.
+ALTER TABLE contact_messages ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'new';
+
+CREATE INDEX idx_contact_messages_status ON contact_messages (status);
+
+-- Every status change is recorded with who made it and when.
+CREATE TABLE IF NOT EXISTS contact_status_transitions (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_contact_status_transitions_contact FOREIGN KEY (contact_id) REFERENCES contact_messages (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_status_transitions_contact_id ON contact_status_transitions (contact_id, created_at);
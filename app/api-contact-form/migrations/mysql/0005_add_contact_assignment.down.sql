@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS contact_assignments;
+
+DROP INDEX idx_contact_messages_queue ON contact_messages;
+DROP INDEX idx_contact_messages_assignee ON contact_messages;
+
+ALTER TABLE contact_messages DROP COLUMN queue;
+ALTER TABLE contact_messages DROP COLUMN assignee;
@@ -0,0 +1,22 @@
+-- The assignee is the team member handling a contact, and the queue the team it is routed to.
+ALTER TABLE contact_messages ADD COLUMN assignee VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE contact_messages ADD COLUMN queue VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_contact_messages_assignee ON contact_messages (assignee);
+CREATE INDEX idx_contact_messages_queue ON contact_messages (queue);
+
+-- Every assignment change is recorded with who made it and when.
+CREATE TABLE IF NOT EXISTS contact_assignments (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    from_assignee VARCHAR(100) NOT NULL DEFAULT '',
+    to_assignee VARCHAR(100) NOT NULL DEFAULT '',
+    from_queue VARCHAR(50) NOT NULL DEFAULT '',
+    to_queue VARCHAR(50) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_contact_assignments_contact FOREIGN KEY (contact_id) REFERENCES contact_messages (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_assignments_contact_id ON contact_assignments (contact_id, created_at);
@@ -0,0 +1,22 @@
+-- Tags categorize contacts, such as sales, support or partnership.
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(9) NOT NULL DEFAULT '',
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_tags_name UNIQUE (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Each row attaches a tag to a contact.
+CREATE TABLE IF NOT EXISTS contact_tags (
+    contact_id BIGINT UNSIGNED NOT NULL,
+    tag_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (contact_id, tag_id),
+    CONSTRAINT fk_contact_tags_contact FOREIGN KEY (contact_id) REFERENCES contact_messages (id) ON DELETE CASCADE,
+    CONSTRAINT fk_contact_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_tags_tag_id ON contact_tags (tag_id);
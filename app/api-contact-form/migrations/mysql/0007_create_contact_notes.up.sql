@@ -0,0 +1,26 @@
+-- Internal notes left by operators on a contact. They are never exposed to the public form.
+CREATE TABLE IF NOT EXISTS contact_notes (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_contact_notes_contact FOREIGN KEY (contact_id) REFERENCES contact_messages (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_notes_contact_id ON contact_notes (contact_id, created_at);
+
+-- Every edit of a note keeps the body it replaced, with who edited it and when.
+CREATE TABLE IF NOT EXISTS contact_note_revisions (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    note_id BIGINT UNSIGNED NOT NULL,
+    body TEXT NOT NULL,
+    editor VARCHAR(100) NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_contact_note_revisions_note FOREIGN KEY (note_id) REFERENCES contact_notes (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_note_revisions_note_id ON contact_note_revisions (note_id, created_at);
@@ -0,0 +1,23 @@
+-- Email conversation with the submitter of a contact. Message IDs keep later emails threaded.
+CREATE TABLE IF NOT EXISTS contact_thread_messages (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    direction VARCHAR(10) NOT NULL,
+    message_id VARCHAR(255) NOT NULL,
+    in_reply_to VARCHAR(255) NOT NULL DEFAULT '',
+    `references` TEXT NOT NULL,
+    from_address VARCHAR(255) NOT NULL,
+    to_address VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    error TEXT NOT NULL,
+    sent_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_contact_thread_messages_message_id UNIQUE (message_id),
+    CONSTRAINT fk_contact_thread_messages_contact FOREIGN KEY (contact_id) REFERENCES contact_messages (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_thread_messages_contact_id ON contact_thread_messages (contact_id, created_at);
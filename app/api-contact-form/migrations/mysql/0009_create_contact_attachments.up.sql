@@ -0,0 +1,16 @@
+-- Files attached to a contact. The content is kept in the file store under storage_key.
+CREATE TABLE IF NOT EXISTS contact_attachments (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    contact_id BIGINT UNSIGNED NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_contact_attachments_storage_key UNIQUE (storage_key),
+    CONSTRAINT fk_contact_attachments_contact FOREIGN KEY (contact_id) REFERENCES contact_messages (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_contact_attachments_contact_id ON contact_attachments (contact_id);
@@ -0,0 +1,8 @@
+ALTER TABLE contact_messages DROP FOREIGN KEY fk_contact_messages_form;
+
+DROP INDEX idx_contact_messages_form_id ON contact_messages;
+
+ALTER TABLE contact_messages DROP COLUMN field_values;
+ALTER TABLE contact_messages DROP COLUMN form_id;
+
+DROP TABLE IF EXISTS forms;
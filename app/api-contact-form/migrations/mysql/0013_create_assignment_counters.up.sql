@@ -0,0 +1,8 @@
+-- The round robin assignment rule takes turns through a counter per queue, incremented
+-- atomically so that concurrent submissions never pick the same turn. Turns start
+-- from the first member of each queue once the table is created.
+CREATE TABLE IF NOT EXISTS assignment_counters (
+    queue VARCHAR(50) NOT NULL,
+    turns BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (queue)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+DROP INDEX ft_contact_messages_search ON contact_messages;
@@ -0,0 +1,17 @@
+-- Add the FULLTEXT index used by contact search. Databases that ran the startup code of
+-- earlier versions may already have it, and MySQL lacks CREATE INDEX IF NOT EXISTS, so the
+-- index is only created when information_schema does not list it yet.
+SET @create_search_index = IF(
+    (SELECT COUNT(*) FROM information_schema.statistics
+     WHERE table_schema = DATABASE()
+       AND table_name = 'contact_messages'
+       AND index_name = 'ft_contact_messages_search') = 0,
+    'CREATE FULLTEXT INDEX ft_contact_messages_search ON contact_messages (full_name, email_address, message_text)',
+    'DO 0'
+);
+
+PREPARE create_search_index FROM @create_search_index;
+
+EXECUTE create_search_index;
+
+DEALLOCATE PREPARE create_search_index;
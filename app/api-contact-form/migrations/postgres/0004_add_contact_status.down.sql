@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS contact_status_transitions;
+
+DROP INDEX IF EXISTS idx_contact_messages_status;
+
+ALTER TABLE contact_messages DROP COLUMN status;
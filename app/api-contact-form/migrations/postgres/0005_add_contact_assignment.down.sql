@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS contact_assignments;
+
+DROP INDEX IF EXISTS idx_contact_messages_queue;
+DROP INDEX IF EXISTS idx_contact_messages_assignee;
+
+ALTER TABLE contact_messages DROP COLUMN queue;
+ALTER TABLE contact_messages DROP COLUMN assignee;
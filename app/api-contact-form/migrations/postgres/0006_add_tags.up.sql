@@ -0,0 +1,19 @@
+-- Tags categorize contacts, such as sales, support or partnership.
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(9) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NULL,
+    CONSTRAINT uni_tags_name UNIQUE (name)
+);
+
+-- Each row attaches a tag to a contact.
+CREATE TABLE IF NOT EXISTS contact_tags (
+    contact_id BIGINT NOT NULL REFERENCES contact_messages (id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NULL,
+    PRIMARY KEY (contact_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_tags_tag_id ON contact_tags (tag_id);
@@ -0,0 +1,22 @@
+-- Internal notes left by operators on a contact. They are never exposed to the public form.
+CREATE TABLE IF NOT EXISTS contact_notes (
+    id BIGSERIAL PRIMARY KEY,
+    contact_id BIGINT NOT NULL REFERENCES contact_messages (id) ON DELETE CASCADE,
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_notes_contact_id ON contact_notes (contact_id, created_at);
+
+-- Every edit of a note keeps the body it replaced, with who edited it and when.
+CREATE TABLE IF NOT EXISTS contact_note_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    note_id BIGINT NOT NULL REFERENCES contact_notes (id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    editor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_note_revisions_note_id ON contact_note_revisions (note_id, created_at);
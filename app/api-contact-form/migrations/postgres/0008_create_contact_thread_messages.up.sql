@@ -0,0 +1,20 @@
+-- Email conversation with the submitter of a contact. Message IDs keep later emails threaded.
+CREATE TABLE IF NOT EXISTS contact_thread_messages (
+    id BIGSERIAL PRIMARY KEY,
+    contact_id BIGINT NOT NULL REFERENCES contact_messages (id) ON DELETE CASCADE,
+    direction VARCHAR(10) NOT NULL,
+    message_id VARCHAR(255) NOT NULL CONSTRAINT uni_contact_thread_messages_message_id UNIQUE,
+    in_reply_to VARCHAR(255) NOT NULL DEFAULT '',
+    "references" TEXT NOT NULL DEFAULT '',
+    from_address VARCHAR(255) NOT NULL,
+    to_address VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_thread_messages_contact_id ON contact_thread_messages (contact_id, created_at);
@@ -0,0 +1,3 @@
+ALTER TABLE contact_attachments DROP COLUMN scanned_at;
+ALTER TABLE contact_attachments DROP COLUMN scan_signature;
+ALTER TABLE contact_attachments DROP COLUMN scan_status;
@@ -0,0 +1,13 @@
+-- Where clients redirect to after a successful submission, and the name and description of
+-- the form in other languages as a JSON object by language code. Labels and placeholders of
+-- the fields are kept with the fields.
+ALTER TABLE forms ADD COLUMN thank_you_url VARCHAR(2048) NOT NULL DEFAULT '';
+ALTER TABLE forms ADD COLUMN translations TEXT NULL;
+
+-- The default form gets placeholders and Indonesian texts, and redirects to the thank-you
+-- page of the client contact form.
+UPDATE forms SET
+    fields = '[{"name":"name","label":"Name","placeholder":"John Doe","type":"text","required":true,"max_length":100,"translations":{"id":{"label":"Nama","placeholder":"Budi Santoso"}}},{"name":"email","label":"Email","placeholder":"john@example.com","type":"email","required":true,"max_length":100,"translations":{"id":{"label":"Email","placeholder":"budi@example.com"}}},{"name":"phone","label":"Phone","placeholder":"+1 555 0100","type":"tel","required":true,"max_length":20,"translations":{"id":{"label":"Telepon","placeholder":"+62 812 3456 7890"}}},{"name":"message","label":"Message","placeholder":"How can we help you?","type":"textarea","required":true,"translations":{"id":{"label":"Pesan","placeholder":"Apa yang bisa kami bantu?"}}}]',
+    thank_you_url = '/thank-you',
+    translations = '{"id":{"name":"Kontak","description":"Formulir kontak bawaan, juga dilayani oleh /contacts."}}'
+WHERE slug = 'contact';
@@ -0,0 +1,7 @@
+-- The round robin assignment rule takes turns through a counter per queue, incremented
+-- atomically so that concurrent submissions never pick the same turn. Turns start
+-- from the first member of each queue once the table is created.
+CREATE TABLE IF NOT EXISTS assignment_counters (
+    queue VARCHAR(50) PRIMARY KEY,
+    turns BIGINT NOT NULL DEFAULT 0
+);
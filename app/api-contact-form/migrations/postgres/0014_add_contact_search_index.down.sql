@@ -0,0 +1 @@
+-- Nothing to remove: see the up migration.
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS contact_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    full_name VARCHAR(100) NOT NULL,
+    email_address VARCHAR(100) NOT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    message_text TEXT NOT NULL,
+    created_at DATETIME NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_messages_deleted_at ON contact_messages (deleted_at);
+
+CREATE INDEX IF NOT EXISTS idx_contact_messages_created_at ON contact_messages (created_at, id);
+
+-- Active contacts used to be marked with a zero date instead of NULL.
+UPDATE contact_messages SET deleted_at = NULL WHERE deleted_at < '1000-01-01';
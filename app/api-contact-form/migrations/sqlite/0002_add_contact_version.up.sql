@@ -0,0 +1,2 @@
+-- The version is incremented on every update and used for optimistic concurrency control.
+ALTER TABLE contact_messages ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
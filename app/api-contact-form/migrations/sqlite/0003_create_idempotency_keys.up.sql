@@ -0,0 +1,13 @@
+-- Idempotency keys let clients retry POST requests without creating duplicates.
+-- A key whose status_code is NULL belongs to a request that is still in progress.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    status_code INTEGER NULL,
+    response_headers TEXT NULL,
+    response_body TEXT NULL,
+    created_at DATETIME NULL,
+    expires_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
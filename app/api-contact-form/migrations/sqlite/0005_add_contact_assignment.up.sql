@@ -0,0 +1,20 @@
+-- The assignee is the team member handling a contact, and the queue the team it is routed to.
+ALTER TABLE contact_messages ADD COLUMN assignee VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE contact_messages ADD COLUMN queue VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_contact_messages_assignee ON contact_messages (assignee);
+CREATE INDEX IF NOT EXISTS idx_contact_messages_queue ON contact_messages (queue);
+
+-- Every assignment change is recorded with who made it and when.
+CREATE TABLE IF NOT EXISTS contact_assignments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    contact_id INTEGER NOT NULL REFERENCES contact_messages (id) ON DELETE CASCADE,
+    from_assignee VARCHAR(100) NOT NULL DEFAULT '',
+    to_assignee VARCHAR(100) NOT NULL DEFAULT '',
+    from_queue VARCHAR(50) NOT NULL DEFAULT '',
+    to_queue VARCHAR(50) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_assignments_contact_id ON contact_assignments (contact_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS contact_note_revisions;
+
+DROP TABLE IF EXISTS contact_notes;
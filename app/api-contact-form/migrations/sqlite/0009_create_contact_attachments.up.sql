@@ -0,0 +1,13 @@
+-- Files attached to a contact. The content is kept in the file store under storage_key.
+CREATE TABLE IF NOT EXISTS contact_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    contact_id INTEGER NOT NULL REFERENCES contact_messages (id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL CONSTRAINT uni_contact_attachments_storage_key UNIQUE,
+    created_at DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_attachments_contact_id ON contact_attachments (contact_id);
@@ -0,0 +1,5 @@
+-- The verdict of the malware scan of an attachment: unscanned, clean, infected, or failed.
+-- Infected files are kept in quarantine and the signature that matched is recorded.
+ALTER TABLE contact_attachments ADD COLUMN scan_status VARCHAR(20) NOT NULL DEFAULT 'unscanned';
+ALTER TABLE contact_attachments ADD COLUMN scan_signature VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE contact_attachments ADD COLUMN scanned_at DATETIME NULL;
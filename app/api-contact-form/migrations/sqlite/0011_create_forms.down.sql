@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_contact_messages_form_id;
+
+ALTER TABLE contact_messages DROP COLUMN field_values;
+ALTER TABLE contact_messages DROP COLUMN form_id;
+
+DROP TABLE IF EXISTS forms;
@@ -0,0 +1,29 @@
+-- Forms describe the fields submitted by the pages of the site, such as marketing, support
+-- or careers. The field schema is kept as a JSON array in fields.
+CREATE TABLE IF NOT EXISTS forms (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    fields TEXT NOT NULL,
+    created_at DATETIME NULL,
+    updated_at DATETIME NULL,
+    CONSTRAINT uni_forms_slug UNIQUE (slug)
+);
+
+-- The default form mirrors the fields of /contacts. It is read-only.
+INSERT INTO forms (slug, name, description, fields, created_at, updated_at) VALUES (
+    'contact',
+    'Contact',
+    'The default contact form, also served by /contacts.',
+    '[{"name":"name","label":"Name","type":"text","required":true,"max_length":100},{"name":"email","label":"Email","type":"email","required":true,"max_length":100},{"name":"phone","label":"Phone","type":"tel","required":true,"max_length":20},{"name":"message","label":"Message","type":"textarea","required":true}]',
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+);
+
+-- The form a contact was submitted through, NULL for the default form, and the values of the
+-- fields of the form that have no column of their own, as a JSON object.
+ALTER TABLE contact_messages ADD COLUMN form_id INTEGER NULL REFERENCES forms (id);
+ALTER TABLE contact_messages ADD COLUMN field_values TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_contact_messages_form_id ON contact_messages (form_id);
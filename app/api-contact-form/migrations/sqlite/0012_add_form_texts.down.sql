@@ -0,0 +1,2 @@
+ALTER TABLE forms DROP COLUMN translations;
+ALTER TABLE forms DROP COLUMN thank_you_url;
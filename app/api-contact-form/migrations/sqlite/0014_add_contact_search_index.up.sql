@@ -0,0 +1,2 @@
+-- Contact search uses a FULLTEXT index on MySQL/MariaDB only. Other databases fall back to
+-- LIKE matching, so there is nothing to add here; the migration keeps versions aligned.
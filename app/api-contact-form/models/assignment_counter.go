@@ -0,0 +1,22 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the AssignmentCounter struct, which keeps the turn of the round robin
+// assignment of a queue.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+// AssignmentCounter counts the contacts of a queue assigned in turn to its members.
+type AssignmentCounter struct {
+	// Queue is the queue the counter belongs to.
+	Queue string `gorm:"primaryKey;column:queue;type:VARCHAR(50)"`
+
+	// Turns is the number of turns taken so far.
+	Turns uint64 `gorm:"column:turns;not null"`
+}
+
+// TableName specifies the table name for the AssignmentCounter model in the database.
+func (AssignmentCounter) TableName() string {
+	return "assignment_counters"
+}
@@ -10,12 +10,14 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Contact represents a contact message submitted through the API.
 type Contact struct {
 	// ID is the unique identifier for each contact message.
-	ID uint `gorm:"primaryKey;column:id;type:BIGINT UNSIGNED AUTO_INCREMENT"`
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
 
 	// FullName is the name of the person submitting the contact message.
 	FullName string `gorm:"column:full_name;type:VARCHAR(100);not null"`
@@ -30,14 +32,58 @@ type Contact struct {
 	Message string `gorm:"column:message_text;type:TEXT;not null"`
 
 	// CreatedAt records the timestamp when the contact message was created.
-	CreatedAt time.Time `gorm:"column:created_at;type:DATETIME;autoCreateTime"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 
 	// UpdatedAt records the timestamp when the contact message was last updated.
-	UpdatedAt time.Time `gorm:"column:updated_at;type:DATETIME;autoUpdateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+
+	// Status is the stage of the contact message in its handling workflow, such as
+	// ContactStatusNew. It only changes through the allowed status transitions.
+	Status string `gorm:"column:status;type:VARCHAR(20);not null;default:new;index"`
+
+	// Assignee identifies the team member handling the contact message.
+	// It is empty while the contact message is unassigned.
+	Assignee string `gorm:"column:assignee;type:VARCHAR(100);not null;default:'';index"`
+
+	// Queue is the team or queue the contact message is routed to. It is empty when the
+	// contact message is not routed to any queue.
+	Queue string `gorm:"column:queue;type:VARCHAR(50);not null;default:'';index"`
+
+	// Version is incremented on every update of the contact message.
+	// It is used for optimistic concurrency control, so that concurrent updates cannot
+	// silently overwrite each other.
+	Version uint `gorm:"column:version;not null;default:1"`
+
+	// FormID is the identifier of the form the contact message was submitted through.
+	// It is nil for the default form.
+	FormID *uint `gorm:"column:form_id;index"`
+
+	// FieldValues holds the submitted values of the fields of the form that have no column
+	// of their own, such as a company or a budget, by field name. It is nil when there are none.
+	FieldValues map[string]interface{} `gorm:"column:field_values;type:TEXT;serializer:json"`
+
+	// Tags are the tags attached to the contact message, loaded whenever the contact
+	// message is retrieved. Attaching and detaching tags goes through ContactTag rows.
+	Tags []Tag `gorm:"many2many:contact_tags;joinForeignKey:contact_id;joinReferences:tag_id"`
+
+	// Attachments are the files attached to the contact message, loaded whenever the contact
+	// message is retrieved. New attachments are created together with the contact message.
+	Attachments []ContactAttachment `gorm:"foreignKey:ContactID"`
+
+	// ThreadMessages are the emails of the conversation held with the submitter. They are not
+	// loaded with the contact message; they are only set to create the email a contact message
+	// was created from together with it.
+	ThreadMessages []ContactThreadMessage `gorm:"foreignKey:ContactID"`
+
+	// NoteCount is the number of internal notes on the contact message. It is not a column;
+	// it is counted whenever the contact message is retrieved.
+	NoteCount int64 `gorm:"-"`
 
-	// DeletedAt records the timestamp when the contact message was deleted.
+	// DeletedAt records the timestamp when the contact message was moved to the trash.
+	// It is NULL while the contact message is active, and GORM excludes trashed contact
+	// messages from queries unless they are explicitly unscoped.
 	// This field is indexed to optimize deletion queries.
-	DeletedAt time.Time `gorm:"column:deleted_at;type:DATETIME;index"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
 }
 
 // TableName specifies the table name for the Contact model in the database.
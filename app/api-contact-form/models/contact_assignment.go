@@ -0,0 +1,47 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the ContactAssignment struct, which records every change of the
+// assignee or queue of a contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+// ContactAssignment records a change of the assignee or queue of a contact.
+type ContactAssignment struct {
+	// ID is the unique identifier for each assignment change.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// ContactID is the identifier of the contact whose assignment changed.
+	ContactID uint `gorm:"column:contact_id;not null;index"`
+
+	// FromAssignee is the assignee of the contact before the change. It is empty if the
+	// contact was unassigned.
+	FromAssignee string `gorm:"column:from_assignee;type:VARCHAR(100);not null"`
+
+	// ToAssignee is the assignee of the contact after the change. It is empty if the
+	// contact was unassigned.
+	ToAssignee string `gorm:"column:to_assignee;type:VARCHAR(100);not null"`
+
+	// FromQueue is the queue of the contact before the change.
+	FromQueue string `gorm:"column:from_queue;type:VARCHAR(50);not null"`
+
+	// ToQueue is the queue of the contact after the change.
+	ToQueue string `gorm:"column:to_queue;type:VARCHAR(50);not null"`
+
+	// Actor identifies who changed the assignment. It is empty for assignments made
+	// automatically by the assignment rules.
+	Actor string `gorm:"column:actor;type:VARCHAR(100);not null"`
+
+	// CreatedAt records the timestamp when the assignment changed.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for the ContactAssignment model in the database.
+func (ContactAssignment) TableName() string {
+	return "contact_assignments"
+}
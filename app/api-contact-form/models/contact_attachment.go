@@ -0,0 +1,67 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the ContactAttachment struct, a file attached to a contact, along with the
+// statuses of the malware scan of the file.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+const (
+	// AttachmentScanUnscanned is the scan status of a file stored while malware scanning is disabled.
+	AttachmentScanUnscanned = "unscanned"
+	// AttachmentScanClean is the scan status of a file in which no malware was found.
+	AttachmentScanClean = "clean"
+	// AttachmentScanInfected is the scan status of a file in which malware was found. The file
+	// is kept in quarantine and cannot be downloaded.
+	AttachmentScanInfected = "infected"
+	// AttachmentScanFailed is the scan status of a file that could not be scanned and was
+	// stored anyway, because scanning fails open.
+	AttachmentScanFailed = "failed"
+)
+
+// ContactAttachment represents a file attached to a contact. The content of the file is kept
+// in the file store under StorageKey.
+type ContactAttachment struct {
+	// ID is the unique identifier for each attachment.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// ContactID is the identifier of the contact the file is attached to.
+	ContactID uint `gorm:"column:contact_id;not null;index"`
+
+	// Filename is the name of the file as given by the submitter.
+	Filename string `gorm:"column:filename;type:VARCHAR(255);not null"`
+
+	// ContentType is the media type of the file, sniffed from its content.
+	ContentType string `gorm:"column:content_type;type:VARCHAR(100);not null"`
+
+	// Size is the length of the file, in bytes.
+	Size int64 `gorm:"column:size;not null"`
+
+	// Checksum is the hex-encoded SHA-256 digest of the file.
+	Checksum string `gorm:"column:checksum;type:CHAR(64);not null"`
+
+	// StorageKey is the key of the file in the file store.
+	StorageKey string `gorm:"column:storage_key;type:VARCHAR(255);not null;unique"`
+
+	// ScanStatus is the verdict of the malware scan of the file, such as AttachmentScanClean.
+	ScanStatus string `gorm:"column:scan_status;type:VARCHAR(20);not null;default:unscanned"`
+
+	// ScanSignature names the malware found in the file. It is empty unless the file is infected.
+	ScanSignature string `gorm:"column:scan_signature;type:VARCHAR(255);not null;default:''"`
+
+	// ScannedAt records the timestamp when the file was scanned. It is nil for unscanned files.
+	ScannedAt *time.Time `gorm:"column:scanned_at"`
+
+	// CreatedAt records the timestamp when the file was attached.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for the ContactAttachment model in the database.
+func (ContactAttachment) TableName() string {
+	return "contact_attachments"
+}
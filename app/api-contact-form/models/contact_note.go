@@ -0,0 +1,62 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the ContactNote struct, an internal comment left by an operator on a
+// contact, and the ContactNoteRevision struct, which keeps the edit history of a note.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+// ContactNote represents an internal comment left by an operator on a contact.
+// Notes are never exposed through the public contact form.
+type ContactNote struct {
+	// ID is the unique identifier for each note.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// ContactID is the identifier of the contact the note belongs to.
+	ContactID uint `gorm:"column:contact_id;not null;index"`
+
+	// Author identifies the operator who wrote the note.
+	Author string `gorm:"column:author;type:VARCHAR(100);not null"`
+
+	// Body is the content of the note.
+	Body string `gorm:"column:body;type:TEXT;not null"`
+
+	// CreatedAt records the timestamp when the note was created.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+
+	// UpdatedAt records the timestamp when the note was last edited.
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for the ContactNote model in the database.
+func (ContactNote) TableName() string {
+	return "contact_notes"
+}
+
+// ContactNoteRevision keeps the body of a note as it was before an edit.
+type ContactNoteRevision struct {
+	// ID is the unique identifier for each revision.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// NoteID is the identifier of the edited note.
+	NoteID uint `gorm:"column:note_id;not null;index"`
+
+	// Body is the content of the note before the edit.
+	Body string `gorm:"column:body;type:TEXT;not null"`
+
+	// Editor identifies the operator who made the edit.
+	Editor string `gorm:"column:editor;type:VARCHAR(100);not null"`
+
+	// CreatedAt records the timestamp of the edit.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for the ContactNoteRevision model in the database.
+func (ContactNoteRevision) TableName() string {
+	return "contact_note_revisions"
+}
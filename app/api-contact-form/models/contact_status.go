@@ -0,0 +1,54 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the statuses of the contact handling workflow and the
+// ContactStatusTransition struct, which records every status change of a contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+// Statuses of the contact handling workflow.
+const (
+	// ContactStatusNew is the status of a contact that nobody has looked at yet.
+	ContactStatusNew = "new"
+	// ContactStatusRead is the status of a contact that has been read.
+	ContactStatusRead = "read"
+	// ContactStatusInProgress is the status of a contact that is being handled.
+	ContactStatusInProgress = "in_progress"
+	// ContactStatusReplied is the status of a contact that has been answered.
+	ContactStatusReplied = "replied"
+	// ContactStatusClosed is the status of a contact that needs no further handling.
+	ContactStatusClosed = "closed"
+	// ContactStatusSpam is the status of a contact that has been marked as spam.
+	ContactStatusSpam = "spam"
+)
+
+// ContactStatusTransition records a change of the status of a contact.
+type ContactStatusTransition struct {
+	// ID is the unique identifier for each status transition.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// ContactID is the identifier of the contact whose status changed.
+	ContactID uint `gorm:"column:contact_id;not null;index"`
+
+	// FromStatus is the status of the contact before the change.
+	FromStatus string `gorm:"column:from_status;type:VARCHAR(20);not null"`
+
+	// ToStatus is the status of the contact after the change.
+	ToStatus string `gorm:"column:to_status;type:VARCHAR(20);not null"`
+
+	// Actor identifies who changed the status. It is empty when unknown.
+	Actor string `gorm:"column:actor;type:VARCHAR(100);not null"`
+
+	// CreatedAt records the timestamp when the status changed.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for the ContactStatusTransition model in the database.
+func (ContactStatusTransition) TableName() string {
+	return "contact_status_transitions"
+}
@@ -0,0 +1,87 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the ContactThreadMessage struct, an email of the conversation held with
+// the submitter of a contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+// Directions of a thread message.
+const (
+	// ThreadDirectionOutbound is the direction of an email sent to the submitter.
+	ThreadDirectionOutbound = "outbound"
+	// ThreadDirectionInbound is the direction of an email received from the submitter.
+	ThreadDirectionInbound = "inbound"
+)
+
+// Delivery statuses of a thread message.
+const (
+	// ThreadStatusPending is the status of an outbound email that is being sent.
+	ThreadStatusPending = "pending"
+	// ThreadStatusSent is the status of an outbound email the SMTP server accepted.
+	ThreadStatusSent = "sent"
+	// ThreadStatusFailed is the status of an outbound email that could not be sent.
+	ThreadStatusFailed = "failed"
+	// ThreadStatusReceived is the status of an inbound email.
+	ThreadStatusReceived = "received"
+)
+
+// ContactThreadMessage represents an email of the conversation held with the submitter of
+// a contact. The Message-ID, In-Reply-To, and References headers of the email are kept so
+// that later emails of the conversation stay threaded in mail clients.
+type ContactThreadMessage struct {
+	// ID is the unique identifier for each thread message.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// ContactID is the identifier of the contact the conversation belongs to.
+	ContactID uint `gorm:"column:contact_id;not null;index"`
+
+	// Direction is ThreadDirectionOutbound or ThreadDirectionInbound.
+	Direction string `gorm:"column:direction;type:VARCHAR(10);not null"`
+
+	// MessageID is the Message-ID header of the email, including angle brackets.
+	MessageID string `gorm:"column:message_id;type:VARCHAR(255);not null;unique"`
+
+	// InReplyTo is the Message-ID of the email this one replies to, if any.
+	InReplyTo string `gorm:"column:in_reply_to;type:VARCHAR(255);not null"`
+
+	// References lists, space-separated, the Message-IDs of the earlier emails of the conversation.
+	References string `gorm:"column:references;type:TEXT;not null"`
+
+	// FromAddress is the sender of the email.
+	FromAddress string `gorm:"column:from_address;type:VARCHAR(255);not null"`
+
+	// ToAddress is the recipient of the email.
+	ToAddress string `gorm:"column:to_address;type:VARCHAR(255);not null"`
+
+	// Subject is the subject of the email.
+	Subject string `gorm:"column:subject;type:VARCHAR(255);not null"`
+
+	// Body is the plain-text content of the email.
+	Body string `gorm:"column:body;type:TEXT;not null"`
+
+	// Actor identifies the operator who sent an outbound email.
+	Actor string `gorm:"column:actor;type:VARCHAR(100);not null"`
+
+	// Status is the delivery status of the email, such as ThreadStatusSent.
+	Status string `gorm:"column:status;type:VARCHAR(20);not null"`
+
+	// Error describes why an outbound email could not be sent.
+	Error string `gorm:"column:error;type:TEXT;not null"`
+
+	// SentAt records the timestamp when the email was sent or received.
+	SentAt *time.Time `gorm:"column:sent_at"`
+
+	// CreatedAt records the timestamp when the thread message was created.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for the ContactThreadMessage model in the database.
+func (ContactThreadMessage) TableName() string {
+	return "contact_thread_messages"
+}
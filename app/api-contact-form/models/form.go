@@ -0,0 +1,132 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the Form struct, which describes the fields a page of the site submits,
+// such as the marketing, support or careers page, and the FormField struct of each field,
+// along with the texts that clients display when they render the form.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+// DefaultFormSlug is the slug of the default form, which mirrors the fields of /contacts.
+// Contacts submitted through it have no form ID.
+const DefaultFormSlug = "contact"
+
+// Types of the fields of a form.
+const (
+	// FormFieldText is a single line of text.
+	FormFieldText = "text"
+	// FormFieldTextarea is text of several lines.
+	FormFieldTextarea = "textarea"
+	// FormFieldEmail is an email address.
+	FormFieldEmail = "email"
+	// FormFieldTel is a phone number.
+	FormFieldTel = "tel"
+	// FormFieldURL is an absolute URL.
+	FormFieldURL = "url"
+	// FormFieldNumber is a number.
+	FormFieldNumber = "number"
+	// FormFieldSelect is one of the options of the field, such as a dropdown.
+	FormFieldSelect = "select"
+	// FormFieldMultiselect is any number of the options of the field.
+	FormFieldMultiselect = "multiselect"
+	// FormFieldCheckbox is true or false, such as a consent checkbox.
+	FormFieldCheckbox = "checkbox"
+)
+
+// Form describes the fields submitted through a page of the site.
+type Form struct {
+	// ID is the unique identifier for each form.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// Slug is the unique name of the form in URLs, such as "careers".
+	Slug string `gorm:"column:slug;type:VARCHAR(50);not null;uniqueIndex:uni_forms_slug"`
+
+	// Name is the display name of the form, such as "Careers".
+	Name string `gorm:"column:name;type:VARCHAR(100);not null"`
+
+	// Description explains what the form is used for. It may be empty.
+	Description string `gorm:"column:description;type:VARCHAR(500);not null"`
+
+	// Fields are the fields of the form, in the order they are displayed.
+	Fields []FormField `gorm:"column:fields;type:TEXT;not null;serializer:json"`
+
+	// ThankYouURL is where clients redirect to after a successful submission, either an
+	// absolute URL or a path of the site, such as "/thank-you". It may be empty.
+	ThankYouURL string `gorm:"column:thank_you_url;type:VARCHAR(2048);not null"`
+
+	// Translations holds the name and description of the form in other languages, by language
+	// code, such as "id". It is nil when there are none.
+	Translations map[string]FormTranslation `gorm:"column:translations;type:TEXT;serializer:json"`
+
+	// CreatedAt records the timestamp when the form was created.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+
+	// UpdatedAt records the timestamp when the form was last updated.
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for the Form model in the database.
+func (Form) TableName() string {
+	return "forms"
+}
+
+// FormField describes a field of a form and the rules its values must follow.
+type FormField struct {
+	// Name is the key of the value of the field in submissions, such as "company".
+	// The fields named name, email, phone and message fill the matching columns of the contact.
+	Name string `json:"name"`
+
+	// Label is the text displayed next to the field, such as "Company".
+	Label string `json:"label"`
+
+	// Placeholder is the hint displayed in the empty field, such as "Acme Inc.". It may be empty.
+	Placeholder string `json:"placeholder,omitempty"`
+
+	// Type is the type of the field, such as FormFieldText.
+	Type string `json:"type"`
+
+	// Required reports whether a value must be submitted for the field.
+	Required bool `json:"required"`
+
+	// MaxLength is the maximum number of characters of a text value. Zero means the default
+	// limit of the type.
+	MaxLength int `json:"max_length,omitempty"`
+
+	// Options are the values a select or multiselect field accepts.
+	Options []string `json:"options,omitempty"`
+
+	// Pattern is a regular expression a text value must match in full. It may be empty.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Translations holds the texts of the field in other languages, by language code.
+	Translations map[string]FormFieldTranslation `json:"translations,omitempty"`
+}
+
+// FormTranslation holds the name and description of a form in another language.
+// Empty texts fall back to those of the form.
+type FormTranslation struct {
+	// Name is the display name of the form.
+	Name string `json:"name,omitempty"`
+
+	// Description explains what the form is used for.
+	Description string `json:"description,omitempty"`
+}
+
+// FormFieldTranslation holds the texts of a form field in another language.
+// Empty texts fall back to those of the field.
+type FormFieldTranslation struct {
+	// Label is the text displayed next to the field.
+	Label string `json:"label,omitempty"`
+
+	// Placeholder is the hint displayed in the empty field.
+	Placeholder string `json:"placeholder,omitempty"`
+
+	// Options maps the options of a select or multiselect field to the texts displayed for
+	// them. Options without a text are displayed as they are.
+	Options map[string]string `json:"options,omitempty"`
+}
@@ -0,0 +1,49 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the IdempotencyKey struct, which records the outcome of a request sent
+// with an Idempotency-Key header, so that retries of the request can be answered with the
+// same response instead of being processed again.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyKey records a request sent with an Idempotency-Key header and its response.
+type IdempotencyKey struct {
+	// Key is the value of the Idempotency-Key header chosen by the client.
+	Key string `gorm:"primaryKey;column:idempotency_key;type:VARCHAR(255)"`
+
+	// RequestHash is the hex-encoded SHA-256 hash of the request method, path, and body.
+	// A retry must have the same hash as the original request.
+	RequestHash string `gorm:"column:request_hash;type:CHAR(64);not null"`
+
+	// StatusCode is the HTTP status code of the stored response.
+	// It is nil while the original request is still in progress.
+	StatusCode *int `gorm:"column:status_code"`
+
+	// ResponseHeaders holds the replayed response headers, encoded as a JSON object.
+	ResponseHeaders string `gorm:"column:response_headers;type:TEXT"`
+
+	// ResponseBody is the body of the stored response.
+	ResponseBody string `gorm:"column:response_body;type:TEXT"`
+
+	// CreatedAt records the timestamp when the key was first received.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+
+	// ExpiresAt records the timestamp after which the key can be reused.
+	ExpiresAt time.Time `gorm:"column:expires_at;not null"`
+}
+
+// TableName specifies the table name for the IdempotencyKey model in the database.
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// Completed reports whether the response of the original request has been stored.
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != nil
+}
@@ -0,0 +1,53 @@
+// Package models defines the data models for the API Contact Form application.
+//
+// This file defines the Tag struct, which labels contacts with a category such as sales or
+// support, and the ContactTag struct, which attaches a tag to a contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package models
+
+import (
+	"time"
+)
+
+// Tag represents a label that categorizes contacts.
+type Tag struct {
+	// ID is the unique identifier for each tag.
+	ID uint `gorm:"primaryKey;column:id;autoIncrement"`
+
+	// Name is the unique, lower-case name of the tag, such as "sales".
+	Name string `gorm:"column:name;type:VARCHAR(50);not null;uniqueIndex:uni_tags_name"`
+
+	// Color is the hexadecimal color used to display the tag, such as "#1e90ff".
+	// It is empty when the tag has no color.
+	Color string `gorm:"column:color;type:VARCHAR(9);not null"`
+
+	// CreatedAt records the timestamp when the tag was created.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+
+	// UpdatedAt records the timestamp when the tag was last updated.
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for the Tag model in the database.
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// ContactTag attaches a tag to a contact.
+type ContactTag struct {
+	// ContactID is the identifier of the tagged contact.
+	ContactID uint `gorm:"primaryKey;column:contact_id;autoIncrement:false"`
+
+	// TagID is the identifier of the tag.
+	TagID uint `gorm:"primaryKey;column:tag_id;autoIncrement:false"`
+
+	// CreatedAt records the timestamp when the tag was attached to the contact.
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for the ContactTag model in the database.
+func (ContactTag) TableName() string {
+	return "contact_tags"
+}
@@ -0,0 +1,71 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// This file defines the ContactAttachmentRepository interface and its GORM-based implementation
+// for managing the records of the files attached to contacts.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"api-contact-form/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContactAttachmentRepository defines the interface for contact attachment data operations.
+type ContactAttachmentRepository interface {
+	// Create adds the records of new attachments to the database.
+	Create(ctx context.Context, attachments []models.ContactAttachment) error
+	// FindByContact retrieves the attachments of a contact, whether it is deleted or not.
+	FindByContact(ctx context.Context, contactID uint) ([]models.ContactAttachment, error)
+	// FindByID retrieves an attachment of a contact by its ID.
+	FindByID(ctx context.Context, contactID uint, id uint) (*models.ContactAttachment, error)
+}
+
+// contactAttachmentRepository is the GORM-based implementation of ContactAttachmentRepository.
+// Every operation is bound to the caller's context and bounded by the query timeout.
+type contactAttachmentRepository struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// NewContactAttachmentRepository creates a new instance of ContactAttachmentRepository with the provided GORM DB.
+// Each operation is cancelled once queryTimeout elapses; zero disables the timeout.
+func NewContactAttachmentRepository(db *gorm.DB, queryTimeout time.Duration) ContactAttachmentRepository {
+	return &contactAttachmentRepository{db, queryTimeout}
+}
+
+// Create adds the records of new attachments to the database.
+// It returns an error if the operation fails.
+func (r *contactAttachmentRepository) Create(ctx context.Context, attachments []models.ContactAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	return contextError(db, db.Create(&attachments).Error)
+}
+
+// FindByContact retrieves the attachments of a contact, whether it is deleted or not.
+// It returns the attachments and an error if the operation fails.
+func (r *contactAttachmentRepository) FindByContact(ctx context.Context, contactID uint) ([]models.ContactAttachment, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var attachments []models.ContactAttachment
+	err := db.Where("contact_id = ?", contactID).Order("id ASC").Find(&attachments).Error
+	return attachments, contextError(db, err)
+}
+
+// FindByID retrieves an attachment of a contact by its ID.
+// It returns the attachment and an error if the attachment is not found on the contact or the operation fails.
+func (r *contactAttachmentRepository) FindByID(ctx context.Context, contactID uint, id uint) (*models.ContactAttachment, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var attachment models.ContactAttachment
+	err := db.Where("id = ? AND contact_id = ?", id, contactID).First(&attachment).Error
+	return &attachment, contextError(db, err)
+}
@@ -0,0 +1,110 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// This file defines the ContactNoteRepository interface and its GORM-based implementation
+// for managing the internal notes left on contacts and their edit history.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"api-contact-form/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContactNoteRepository defines the interface for contact note data operations.
+type ContactNoteRepository interface {
+	// Create adds a new note to the database.
+	Create(ctx context.Context, note *models.ContactNote) error
+	// FindByContact retrieves the notes of a contact, oldest first.
+	FindByContact(ctx context.Context, contactID uint) ([]models.ContactNote, error)
+	// FindByID retrieves a note of a contact by its ID.
+	FindByID(ctx context.Context, contactID uint, id uint) (*models.ContactNote, error)
+	// Update writes the body of an existing note and records the revision it replaces.
+	Update(ctx context.Context, note *models.ContactNote, revision *models.ContactNoteRevision) error
+	// Delete permanently removes a note along with its edit history.
+	Delete(ctx context.Context, note *models.ContactNote) error
+	// FindRevisions retrieves the edit history of a note, oldest first.
+	FindRevisions(ctx context.Context, noteID uint) ([]models.ContactNoteRevision, error)
+}
+
+// contactNoteRepository is the GORM-based implementation of ContactNoteRepository.
+// Every operation is bound to the caller's context and bounded by the query timeout.
+type contactNoteRepository struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// NewContactNoteRepository creates a new instance of ContactNoteRepository with the provided GORM DB.
+// Each operation is cancelled once queryTimeout elapses; zero disables the timeout.
+func NewContactNoteRepository(db *gorm.DB, queryTimeout time.Duration) ContactNoteRepository {
+	return &contactNoteRepository{db, queryTimeout}
+}
+
+// Create adds a new note to the database.
+// It returns an error if the operation fails.
+func (r *contactNoteRepository) Create(ctx context.Context, note *models.ContactNote) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	return contextError(db, db.Create(note).Error)
+}
+
+// FindByContact retrieves the notes of a contact, oldest first.
+// It returns the notes and an error if the operation fails.
+func (r *contactNoteRepository) FindByContact(ctx context.Context, contactID uint) ([]models.ContactNote, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var notes []models.ContactNote
+	err := db.Where("contact_id = ?", contactID).Order("created_at ASC, id ASC").Find(&notes).Error
+	return notes, contextError(db, err)
+}
+
+// FindByID retrieves a note of a contact by its ID.
+// It returns the note and an error if the note is not found on the contact or the operation fails.
+func (r *contactNoteRepository) FindByID(ctx context.Context, contactID uint, id uint) (*models.ContactNote, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var note models.ContactNote
+	err := db.Where("id = ? AND contact_id = ?", id, contactID).First(&note).Error
+	return &note, contextError(db, err)
+}
+
+// Update writes the body of an existing note and records the revision it replaces in a
+// single transaction.
+// It returns gorm.ErrRecordNotFound if the note no longer exists, or an error if the operation fails.
+func (r *contactNoteRepository) Update(ctx context.Context, note *models.ContactNote, revision *models.ContactNoteRevision) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(note).Select("body").Updates(note)
+		if err := writeResult(result, gorm.ErrRecordNotFound); err != nil {
+			return err
+		}
+		return tx.Create(revision).Error
+	})
+	return contextError(db, err)
+}
+
+// Delete permanently removes a note from the database. The database removes its edit
+// history through the foreign key of the contact_note_revisions table.
+// It returns an error if the operation fails.
+func (r *contactNoteRepository) Delete(ctx context.Context, note *models.ContactNote) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	return contextError(db, db.Delete(note).Error)
+}
+
+// FindRevisions retrieves the edit history of a note, oldest first.
+// It returns the revisions and an error if the operation fails.
+func (r *contactNoteRepository) FindRevisions(ctx context.Context, noteID uint) ([]models.ContactNoteRevision, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var revisions []models.ContactNoteRevision
+	err := db.Where("note_id = ?", noteID).Order("created_at ASC, id ASC").Find(&revisions).Error
+	return revisions, contextError(db, err)
+}
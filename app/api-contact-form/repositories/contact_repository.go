@@ -10,66 +10,697 @@ package repositories
 
 import (
 	"api-contact-form/models"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// contactSortColumns whitelists the sort keys accepted by FindAll and maps them to table columns.
+var contactSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "full_name",
+	"email":      "email_address",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// ContactFilter describes the filtering, sorting, and paging criteria used when listing contacts.
+type ContactFilter struct {
+	// Name matches contacts whose full name contains the value.
+	Name string
+	// Email matches contacts whose email address contains the value.
+	Email string
+	// Statuses matches contacts having any of the given statuses.
+	Statuses []string
+	// Assignee matches contacts assigned to the given team member.
+	Assignee string
+	// Queue matches contacts routed to the given queue.
+	Queue string
+	// Tags matches contacts having any of the tags with the given names, or all of them
+	// when MatchAllTags is set.
+	Tags []string
+	// MatchAllTags requires contacts to have every tag in Tags instead of any of them.
+	MatchAllTags bool
+	// CreatedFrom matches contacts created at or after the given time.
+	CreatedFrom *time.Time
+	// CreatedTo matches contacts created before the given time.
+	CreatedTo *time.Time
+	// Sort is a whitelisted sort key, optionally prefixed with '-' for descending order.
+	Sort string
+	// Limit is the maximum number of contacts to return. Zero means no limit.
+	Limit int
+	// Offset is the number of contacts to skip.
+	Offset int
+}
+
+// contactUpdatableColumns lists the columns written by Update when no columns are given.
+var contactUpdatableColumns = []string{"full_name", "email_address", "phone_number", "message_text"}
+
+// ErrInvalidCursor is returned when an encoded contact cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrTagNotFound is returned when tags are attached to contacts but some of the tags do not exist.
+var ErrTagNotFound = errors.New("tag not found")
+
+// ErrVersionMismatch is returned by conditional writes when the contact was changed or
+// removed since it was read, so that its version no longer matches.
+var ErrVersionMismatch = errors.New("contact version mismatch")
+
+// ContactCursor identifies a position in the contact listing ordered by
+// (created_at, id), newest first.
+type ContactCursor struct {
+	// CreatedAt is the creation time of the contact at the cursor position.
+	CreatedAt time.Time `json:"t"`
+	// ID is the identifier of the contact at the cursor position.
+	ID uint `json:"i"`
+	// Backward indicates that the cursor pages towards newer contacts.
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode returns the opaque, URL-safe string representation of the cursor.
+func (c ContactCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeContactCursor parses a cursor previously produced by ContactCursor.Encode.
+// It returns ErrInvalidCursor if the value is malformed.
+func DecodeContactCursor(value string) (*ContactCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor ContactCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// ContactCursorPage holds a page of contacts fetched with keyset pagination.
+type ContactCursorPage struct {
+	// Contacts are the contacts on the page, newest first.
+	Contacts []models.Contact
+	// Next points to the following (older) page, or is nil on the last page.
+	Next *ContactCursor
+	// Prev points to the preceding (newer) page, or is nil on the first page.
+	Prev *ContactCursor
+}
+
 // ContactRepository defines the interface for contact data operations.
 type ContactRepository interface {
-	// Create adds a new contact to the database.
-	Create(contact *models.Contact) error
-	// FindAll retrieves all non-deleted contacts from the database.
-	FindAll() ([]models.Contact, error)
+	// Create adds a new contact to the database. If the contact is assigned or routed to a
+	// queue, the initial assignment is recorded as well.
+	Create(ctx context.Context, contact *models.Contact) error
+	// FindAll retrieves the non-deleted contacts matching the filter, along with
+	// the total number of matching contacts before paging is applied.
+	FindAll(ctx context.Context, filter ContactFilter) ([]models.Contact, int64, error)
+	// FindByCursor retrieves up to limit non-deleted contacts matching the filter that come
+	// after the cursor in (created_at, id) order, newest first. A nil cursor starts at the
+	// newest contact. The Sort, Limit, and Offset fields of the filter are ignored.
+	FindByCursor(ctx context.Context, filter ContactFilter, cursor *ContactCursor, limit int) (*ContactCursorPage, error)
+	// FindTrashed retrieves the deleted contacts matching the filter, along with
+	// the total number of matching contacts before paging is applied.
+	FindTrashed(ctx context.Context, filter ContactFilter) ([]models.Contact, int64, error)
 	// FindByID retrieves a contact by its ID, ensuring it is not deleted.
-	FindByID(id uint) (*models.Contact, error)
-	// Update modifies an existing contact in the database.
-	Update(contact *models.Contact) error
-	// Delete marks a contact as deleted in the database.
-	Delete(contact *models.Contact) error
+	FindByID(ctx context.Context, id uint) (*models.Contact, error)
+	// FindTrashedByID retrieves a deleted contact by its ID.
+	FindTrashedByID(ctx context.Context, id uint) (*models.Contact, error)
+	// FindByIDWithTrashed retrieves a contact by its ID, whether it is deleted or not.
+	FindByIDWithTrashed(ctx context.Context, id uint) (*models.Contact, error)
+	// Update modifies the given columns, or all columns, of an existing contact in the database,
+	// provided that its version has not changed since it was read.
+	Update(ctx context.Context, contact *models.Contact, columns ...string) error
+	// Delete marks a contact as deleted in the database, provided that its version has not changed.
+	Delete(ctx context.Context, contact *models.Contact) error
+	// Restore clears the deleted mark of a contact in the database.
+	Restore(ctx context.Context, contact *models.Contact) error
+	// Purge permanently removes a contact from the database, provided that its version has not changed.
+	Purge(ctx context.Context, contact *models.Contact) error
+	// UpdateStatus changes the status of an existing contact and records the transition,
+	// provided that its version has not changed since it was read.
+	UpdateStatus(ctx context.Context, contact *models.Contact, transition *models.ContactStatusTransition) error
+	// FindStatusTransitions retrieves the status transitions of a contact, oldest first.
+	FindStatusTransitions(ctx context.Context, contactID uint) ([]models.ContactStatusTransition, error)
+	// UpdateAssignment changes the assignee and queue of an existing contact and records the change,
+	// provided that its version has not changed since it was read.
+	UpdateAssignment(ctx context.Context, contact *models.Contact, assignment *models.ContactAssignment) error
+	// FindAssignments retrieves the assignment changes of a contact, oldest first.
+	FindAssignments(ctx context.Context, contactID uint) ([]models.ContactAssignment, error)
+	// FindByIDs retrieves the non-deleted contacts with the given IDs, ordered by ID.
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Contact, error)
+	// ChangeTags attaches tags to and detaches tags from non-deleted contacts in a single
+	// transaction. Attaching a tag that a contact already has is not an error.
+	ChangeTags(ctx context.Context, contactIDs []uint, add []uint, remove []uint) error
+	// NextAssignmentTurn increments the assignment counter of a queue and returns its new value,
+	// starting at 1. Concurrent calls for the same queue return distinct values.
+	NextAssignmentTurn(ctx context.Context, queue string) (uint64, error)
 }
 
 // contactRepository is the GORM-based implementation of ContactRepository.
+// Every operation is bound to the caller's context and bounded by the query timeout.
 type contactRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	timeout time.Duration
 }
 
 // NewContactRepository creates a new instance of ContactRepository with the provided GORM DB.
-func NewContactRepository(db *gorm.DB) ContactRepository {
-	return &contactRepository{db}
+// Each operation is cancelled once queryTimeout elapses; zero disables the timeout.
+func NewContactRepository(db *gorm.DB, queryTimeout time.Duration) ContactRepository {
+	return &contactRepository{db, queryTimeout}
 }
 
-// Create adds a new contact to the database.
+// Create adds a new contact to the database, along with its initial assignment if the
+// contact is assigned or routed to a queue.
 // It returns an error if the operation fails.
-func (r *contactRepository) Create(contact *models.Contact) error {
-	return r.db.Create(contact).Error
+func (r *contactRepository) Create(ctx context.Context, contact *models.Contact) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(contact).Error; err != nil {
+			return err
+		}
+		if contact.Assignee == "" && contact.Queue == "" {
+			return nil
+		}
+		return tx.Create(&models.ContactAssignment{
+			ContactID:  contact.ID,
+			ToAssignee: contact.Assignee,
+			ToQueue:    contact.Queue,
+		}).Error
+	})
+	return contextError(db, err)
 }
 
-// FindAll retrieves all non-deleted contacts from the database.
-// It returns a slice of contacts and an error if the operation fails.
-func (r *contactRepository) FindAll() ([]models.Contact, error) {
+// FindAll retrieves the non-deleted contacts matching the filter from the database.
+// It returns a page of contacts, the total number of matching contacts, and an error if the operation fails.
+func (r *contactRepository) FindAll(ctx context.Context, filter ContactFilter) ([]models.Contact, int64, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	contacts, total, err := r.findPage(db.Model(&models.Contact{}), filter)
+	return contacts, total, contextError(db, err)
+}
+
+// FindTrashed retrieves the deleted contacts matching the filter from the database.
+// It returns a page of contacts, the total number of matching contacts, and an error if the operation fails.
+func (r *contactRepository) FindTrashed(ctx context.Context, filter ContactFilter) ([]models.Contact, int64, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	contacts, total, err := r.findPage(db.Unscoped().Model(&models.Contact{}).Where("deleted_at IS NOT NULL"), filter)
+	return contacts, total, contextError(db, err)
+}
+
+// findPage applies the filter to the query, counts the matching contacts, and fetches the requested page.
+func (r *contactRepository) findPage(query *gorm.DB, filter ContactFilter) ([]models.Contact, int64, error) {
 	var contacts []models.Contact
-	err := r.db.Where("deleted_at = ?", "0000-00-00 00:00:00").Find(&contacts).Error
-	return contacts, err
+	var total int64
+
+	query = r.applyFilter(query, filter)
+
+	// Count the matching contacts before paging is applied.
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply sorting and paging, then fetch the requested page.
+	query = query.Order(contactOrder(filter.Sort))
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+	if err := preloadAssociations(query).Find(&contacts).Error; err != nil {
+		return nil, 0, err
+	}
+	err := countNotes(query, contactPointers(contacts)...)
+	return contacts, total, err
+}
+
+// FindByCursor retrieves a page of non-deleted contacts using keyset pagination on (created_at, id).
+// One extra row is fetched to detect whether more contacts exist beyond the page.
+// It returns the page with its next and previous cursors and an error if the operation fails.
+func (r *contactRepository) FindByCursor(ctx context.Context, filter ContactFilter, cursor *ContactCursor, limit int) (*ContactCursorPage, error) {
+	if limit < 1 {
+		limit = 1
+	}
+	backward := cursor != nil && cursor.Backward
+
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+
+	query := r.applyFilter(db.Model(&models.Contact{}), filter)
+	if cursor != nil {
+		if backward {
+			query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+		} else {
+			query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+		}
+	}
+
+	// Walk towards newer contacts when paging backward, then restore newest-first order.
+	if backward {
+		query = query.Order("created_at ASC, id ASC")
+	} else {
+		query = query.Order("created_at DESC, id DESC")
+	}
+
+	var contacts []models.Contact
+	if err := preloadAssociations(query).Limit(limit + 1).Find(&contacts).Error; err != nil {
+		return nil, contextError(db, err)
+	}
+	hasMore := len(contacts) > limit
+	if hasMore {
+		contacts = contacts[:limit]
+	}
+	if err := countNotes(db, contactPointers(contacts)...); err != nil {
+		return nil, contextError(db, err)
+	}
+	if backward {
+		for i, j := 0, len(contacts)-1; i < j; i, j = i+1, j-1 {
+			contacts[i], contacts[j] = contacts[j], contacts[i]
+		}
+	}
+
+	page := &ContactCursorPage{Contacts: contacts}
+	if len(contacts) == 0 {
+		return page, nil
+	}
+	first, last := contacts[0], contacts[len(contacts)-1]
+	if backward || hasMore {
+		page.Next = &ContactCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	if (backward && hasMore) || (!backward && cursor != nil) {
+		page.Prev = &ContactCursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true}
+	}
+	return page, nil
 }
 
 // FindByID retrieves a contact by its ID, ensuring it is not deleted.
 // It returns the contact and an error if the contact is not found or the operation fails.
-func (r *contactRepository) FindByID(id uint) (*models.Contact, error) {
+func (r *contactRepository) FindByID(ctx context.Context, id uint) (*models.Contact, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
 	var contact models.Contact
-	err := r.db.Where("id = ? AND deleted_at = ?", id, "0000-00-00 00:00:00").First(&contact).Error
-	return &contact, err
+	err := preloadAssociations(db).Where("id = ?", id).First(&contact).Error
+	if err == nil {
+		err = countNotes(db, &contact)
+	}
+	return &contact, contextError(db, err)
+}
+
+// FindTrashedByID retrieves a deleted contact by its ID.
+// It returns the contact and an error if the contact is not found in the trash or the operation fails.
+func (r *contactRepository) FindTrashedByID(ctx context.Context, id uint) (*models.Contact, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var contact models.Contact
+	err := preloadAssociations(db.Unscoped()).Where("id = ? AND deleted_at IS NOT NULL", id).First(&contact).Error
+	if err == nil {
+		err = countNotes(db, &contact)
+	}
+	return &contact, contextError(db, err)
+}
+
+// FindByIDWithTrashed retrieves a contact by its ID, including deleted contacts.
+// It returns the contact and an error if the contact is not found or the operation fails.
+func (r *contactRepository) FindByIDWithTrashed(ctx context.Context, id uint) (*models.Contact, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var contact models.Contact
+	err := preloadAssociations(db.Unscoped()).Where("id = ?", id).First(&contact).Error
+	if err == nil {
+		err = countNotes(db, &contact)
+	}
+	return &contact, contextError(db, err)
+}
+
+// Update modifies an existing contact in the database, provided that it still has the version
+// it was read with. Only the given columns, by their database names, are written, or all
+// updatable columns if none are given. The version is incremented in the same statement,
+// which makes the check and the update atomic.
+// It returns ErrVersionMismatch if the contact was changed or removed since it was read,
+// or an error if the operation fails.
+func (r *contactRepository) Update(ctx context.Context, contact *models.Contact, columns ...string) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+
+	if len(columns) == 0 {
+		columns = contactUpdatableColumns
+	}
+
+	version := contact.Version
+	contact.Version++
+	result := db.Model(contact).
+		Where("version = ?", version).
+		Select(append([]string{"version"}, columns...)).
+		Updates(contact)
+	err := writeResult(result, ErrVersionMismatch)
+	if err != nil {
+		contact.Version = version
+	}
+	return contextError(db, err)
 }
 
-// Update modifies an existing contact in the database.
+// Delete marks a contact as deleted in the database by setting the DeletedAt field,
+// provided that it still has the version it was read with.
+// It returns ErrVersionMismatch if the contact was changed or removed since it was read,
+// or an error if the operation fails.
+func (r *contactRepository) Delete(ctx context.Context, contact *models.Contact) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Where("version = ?", contact.Version).Delete(contact)
+	return contextError(db, writeResult(result, ErrVersionMismatch))
+}
+
+// Restore clears the DeletedAt field of a contact in the database, moving it out of the trash.
 // It returns an error if the operation fails.
-func (r *contactRepository) Update(contact *models.Contact) error {
-	return r.db.Save(contact).Error
+func (r *contactRepository) Restore(ctx context.Context, contact *models.Contact) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	err := db.Unscoped().Model(contact).Update("deleted_at", nil).Error
+	if err == nil {
+		contact.DeletedAt = gorm.DeletedAt{}
+	}
+	return contextError(db, err)
+}
+
+// Purge permanently removes a contact from the database, provided that it still has the
+// version it was read with.
+// It returns ErrVersionMismatch if the contact was changed or removed since it was read,
+// or an error if the operation fails.
+func (r *contactRepository) Purge(ctx context.Context, contact *models.Contact) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Unscoped().Where("version = ?", contact.Version).Delete(contact)
+	return contextError(db, writeResult(result, ErrVersionMismatch))
+}
+
+// UpdateStatus writes the status of an existing contact and records the status transition in a
+// single transaction, provided that the contact still has the version it was read with.
+// The version is incremented in the same statement.
+// It returns ErrVersionMismatch if the contact was changed or removed since it was read,
+// or an error if the operation fails.
+func (r *contactRepository) UpdateStatus(ctx context.Context, contact *models.Contact, transition *models.ContactStatusTransition) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+
+	version := contact.Version
+	err := db.Transaction(func(tx *gorm.DB) error {
+		contact.Version++
+		result := tx.Model(contact).
+			Where("version = ?", version).
+			Select("status", "version").
+			Updates(contact)
+		if err := writeResult(result, ErrVersionMismatch); err != nil {
+			return err
+		}
+		return tx.Create(transition).Error
+	})
+	if err != nil {
+		contact.Version = version
+	}
+	return contextError(db, err)
+}
+
+// FindStatusTransitions retrieves the status transitions of a contact, oldest first.
+// It returns the transitions and an error if the operation fails.
+func (r *contactRepository) FindStatusTransitions(ctx context.Context, contactID uint) ([]models.ContactStatusTransition, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var transitions []models.ContactStatusTransition
+	err := db.Where("contact_id = ?", contactID).Order("created_at ASC, id ASC").Find(&transitions).Error
+	return transitions, contextError(db, err)
+}
+
+// UpdateAssignment changes the assignee and queue of a contact and records the change in a
+// single transaction, provided that the contact still has the version it was read with.
+// The version of the contact is incremented.
+// It returns ErrVersionMismatch if the contact was changed or removed since it was read,
+// or an error if the operation fails.
+func (r *contactRepository) UpdateAssignment(ctx context.Context, contact *models.Contact, assignment *models.ContactAssignment) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+
+	version := contact.Version
+	err := db.Transaction(func(tx *gorm.DB) error {
+		contact.Version++
+		result := tx.Model(contact).
+			Where("version = ?", version).
+			Select("assignee", "queue", "version").
+			Updates(contact)
+		if err := writeResult(result, ErrVersionMismatch); err != nil {
+			return err
+		}
+		return tx.Create(assignment).Error
+	})
+	if err != nil {
+		contact.Version = version
+	}
+	return contextError(db, err)
+}
+
+// FindAssignments retrieves the assignment changes of a contact, oldest first.
+// It returns the assignment changes and an error if the operation fails.
+func (r *contactRepository) FindAssignments(ctx context.Context, contactID uint) ([]models.ContactAssignment, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var assignments []models.ContactAssignment
+	err := db.Where("contact_id = ?", contactID).Order("created_at ASC, id ASC").Find(&assignments).Error
+	return assignments, contextError(db, err)
+}
+
+// FindByIDs retrieves the non-deleted contacts with the given IDs, ordered by ID.
+// It returns the contacts found and an error if the operation fails.
+func (r *contactRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Contact, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var contacts []models.Contact
+	err := preloadAssociations(db).Where("id IN ?", ids).Order("id ASC").Find(&contacts).Error
+	if err == nil {
+		err = countNotes(db, contactPointers(contacts)...)
+	}
+	return contacts, contextError(db, err)
+}
+
+// ChangeTags attaches the add tags to and detaches the remove tags from every given contact
+// in a single transaction.
+// It returns gorm.ErrRecordNotFound if a contact does not exist or is deleted, ErrTagNotFound
+// if a tag to attach does not exist, or an error if the operation fails.
+func (r *contactRepository) ChangeTags(ctx context.Context, contactIDs []uint, add []uint, remove []uint) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+
+	contactIDs, add, remove = uniqueIDs(contactIDs), uniqueIDs(add), uniqueIDs(remove)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Make sure that every contact and every tag to attach exists.
+		var count int64
+		if err := tx.Model(&models.Contact{}).Where("id IN ?", contactIDs).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(contactIDs)) {
+			return gorm.ErrRecordNotFound
+		}
+		if len(add) > 0 {
+			if err := tx.Model(&models.Tag{}).Where("id IN ?", add).Count(&count).Error; err != nil {
+				return err
+			}
+			if count != int64(len(add)) {
+				return ErrTagNotFound
+			}
+		}
+
+		// Detach, then attach, skipping tags that are already attached.
+		if len(remove) > 0 {
+			err := tx.Where("contact_id IN ? AND tag_id IN ?", contactIDs, remove).Delete(&models.ContactTag{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(add) > 0 {
+			rows := make([]models.ContactTag, 0, len(contactIDs)*len(add))
+			for _, contactID := range contactIDs {
+				for _, tagID := range add {
+					rows = append(rows, models.ContactTag{ContactID: contactID, TagID: tagID})
+				}
+			}
+			return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+		}
+		return nil
+	})
+	return contextError(db, err)
 }
 
-// Delete marks a contact as deleted in the database by setting the DeletedAt field.
+// NextAssignmentTurn increments the assignment counter of a queue and returns its new value.
+// The counter is created on first use. The update locks the counter until the transaction
+// commits, so concurrent calls for the same queue are serialized and return distinct values.
 // It returns an error if the operation fails.
-func (r *contactRepository) Delete(contact *models.Contact) error {
-	contact.DeletedAt = time.Now()
-	return r.db.Save(contact).Error
+func (r *contactRepository) NextAssignmentTurn(ctx context.Context, queue string) (uint64, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var counter models.AssignmentCounter
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.AssignmentCounter{Queue: queue}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.AssignmentCounter{}).Where("queue = ?", queue).
+			Update("turns", gorm.Expr("turns + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Where("queue = ?", queue).First(&counter).Error
+	})
+	return counter.Turns, contextError(db, err)
+}
+
+// applyFilter adds the WHERE conditions described by the filter to the query.
+func (r *contactRepository) applyFilter(query *gorm.DB, filter ContactFilter) *gorm.DB {
+	if filter.Name != "" {
+		query = query.Where("full_name LIKE ? ESCAPE '!'", "%"+escapeLike(filter.Name)+"%")
+	}
+	if filter.Email != "" {
+		query = query.Where("email_address LIKE ? ESCAPE '!'", "%"+escapeLike(filter.Email)+"%")
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Assignee != "" {
+		query = query.Where("assignee = ?", filter.Assignee)
+	}
+	if filter.Queue != "" {
+		query = query.Where("queue = ?", filter.Queue)
+	}
+	if tags := uniqueStrings(filter.Tags); len(tags) > 0 {
+		tagged := "SELECT contact_tags.contact_id FROM contact_tags" +
+			" JOIN tags ON tags.id = contact_tags.tag_id WHERE tags.name IN ?"
+		if filter.MatchAllTags {
+			tagged += " GROUP BY contact_tags.contact_id HAVING COUNT(*) = ?"
+			query = query.Where("id IN ("+tagged+")", tags, len(tags))
+		} else {
+			query = query.Where("id IN ("+tagged+")", tags)
+		}
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	return query
+}
+
+// contactOrder converts a whitelisted sort key into an ORDER BY clause.
+// Unknown keys fall back to newest first. The ID is always used as a tie-breaker
+// so that paging is deterministic.
+func contactOrder(sort string) string {
+	direction := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	column, ok := contactSortColumns[sort]
+	if !ok {
+		return "created_at DESC, id DESC"
+	}
+	if column == "id" {
+		return "id " + direction
+	}
+	return column + " " + direction + ", id " + direction
+}
+
+// escapeLike escapes the LIKE wildcard characters in value using '!' as the escape character.
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+	return replacer.Replace(value)
+}
+
+// writeResult returns the error of a conditional write, or noRows if the write matched no rows.
+func writeResult(result *gorm.DB, noRows error) error {
+	if result.Error == nil && result.RowsAffected == 0 {
+		return noRows
+	}
+	return result.Error
+}
+
+// preloadAssociations makes the query load the tags of the contacts it retrieves, ordered by
+// name, and their attachments, in the order they were attached.
+func preloadAssociations(query *gorm.DB) *gorm.DB {
+	return query.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name ASC")
+	}).Preload("Attachments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("contact_attachments.id ASC")
+	})
+}
+
+// countNotes sets the NoteCount of the contacts, counting their notes with a single query.
+func countNotes(db *gorm.DB, contacts ...*models.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(contacts))
+	for _, contact := range contacts {
+		ids = append(ids, contact.ID)
+	}
+
+	var counts []struct {
+		ContactID uint
+		NoteCount int64
+	}
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Model(&models.ContactNote{}).
+		Select("contact_id, COUNT(*) AS note_count").
+		Where("contact_id IN ?", ids).
+		Group("contact_id").
+		Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	byContact := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		byContact[count.ContactID] = count.NoteCount
+	}
+	for _, contact := range contacts {
+		contact.NoteCount = byContact[contact.ID]
+	}
+	return nil
+}
+
+// contactPointers returns pointers to the elements of contacts, so that they can be changed in place.
+func contactPointers(contacts []models.Contact) []*models.Contact {
+	pointers := make([]*models.Contact, 0, len(contacts))
+	for i := range contacts {
+		pointers = append(pointers, &contacts[i])
+	}
+	return pointers
+}
+
+// uniqueIDs returns the IDs without duplicates, in their original order.
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// uniqueStrings returns the values without duplicates, in their original order.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
 }
@@ -0,0 +1,150 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// It defines the ContactSearcher interface and its implementations for keyword
+// searches across contact messages: a FULLTEXT-based searcher for MySQL/MariaDB
+// and a portable LIKE-based fallback for other databases.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"api-contact-form/models"
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContactSearchResult holds a contact matched by a search along with its relevance score.
+type ContactSearchResult struct {
+	models.Contact
+
+	// Score is the relevance of the contact to the search query. Higher is more relevant.
+	Score float64 `gorm:"column:score"`
+}
+
+// ContactSearcher defines the interface for keyword searches across contact messages.
+type ContactSearcher interface {
+	// Search retrieves non-deleted contacts whose name, email address, or message matches
+	// the query, ordered by relevance, along with the total number of matching contacts.
+	Search(ctx context.Context, query string, limit, offset int) ([]ContactSearchResult, int64, error)
+}
+
+// NewContactSearcher creates the ContactSearcher best suited to the database behind the provided GORM DB.
+// MySQL/MariaDB use the FULLTEXT index on contact_messages; other databases fall back to LIKE matching.
+// Each search is cancelled once queryTimeout elapses; zero disables the timeout.
+func NewContactSearcher(db *gorm.DB, queryTimeout time.Duration) ContactSearcher {
+	if db.Dialector.Name() == "mysql" {
+		return &fulltextContactSearcher{db, queryTimeout}
+	}
+	return &likeContactSearcher{db, queryTimeout}
+}
+
+// fulltextContactSearcher searches contacts using a MySQL/MariaDB FULLTEXT index.
+type fulltextContactSearcher struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// fulltextMatch is the MATCH expression covering the columns of the FULLTEXT index.
+const fulltextMatch = "MATCH(full_name, email_address, message_text) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+// Search retrieves contacts matching the query using natural language FULLTEXT search.
+// It returns the matching contacts ranked by relevance, the total number of matches, and an error if the operation fails.
+func (s *fulltextContactSearcher) Search(ctx context.Context, query string, limit, offset int) ([]ContactSearchResult, int64, error) {
+	var results []ContactSearchResult
+	var total int64
+
+	db, cancel := session(ctx, s.db, s.timeout)
+	defer cancel()
+
+	base := db.Model(&models.Contact{}).Where(fulltextMatch, query)
+
+	// Count the matching contacts before paging is applied.
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, contextError(db, err)
+	}
+
+	err := preloadAssociations(base).Select("*, "+fulltextMatch+" AS score", query).
+		Order("score DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&results).Error
+	if err == nil {
+		err = countNotes(db, searchResultContacts(results)...)
+	}
+	return results, total, contextError(db, err)
+}
+
+// likeContactSearcher searches contacts using portable LIKE matching.
+// Every search term must appear in at least one of the searched columns, and
+// contacts are ranked by the number of column matches across all terms.
+type likeContactSearcher struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// likeSearchColumns lists the columns matched by the LIKE-based searcher.
+var likeSearchColumns = []string{"full_name", "email_address", "message_text"}
+
+// Search retrieves contacts matching every term of the query using LIKE matching.
+// It returns the matching contacts ranked by relevance, the total number of matches, and an error if the operation fails.
+func (s *likeContactSearcher) Search(ctx context.Context, query string, limit, offset int) ([]ContactSearchResult, int64, error) {
+	var results []ContactSearchResult
+	var total int64
+
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return results, 0, nil
+	}
+
+	db, cancel := session(ctx, s.db, s.timeout)
+	defer cancel()
+
+	base := db.Model(&models.Contact{})
+
+	// Build the per-term match conditions and the relevance score expression.
+	var scoreParts []string
+	var scoreArgs []interface{}
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		var matchParts []string
+		var matchArgs []interface{}
+		for _, column := range likeSearchColumns {
+			condition := "LOWER(" + column + ") LIKE ? ESCAPE '!'"
+			matchParts = append(matchParts, condition)
+			matchArgs = append(matchArgs, pattern)
+			scoreParts = append(scoreParts, "CASE WHEN "+condition+" THEN 1 ELSE 0 END")
+			scoreArgs = append(scoreArgs, pattern)
+		}
+		base = base.Where("("+strings.Join(matchParts, " OR ")+")", matchArgs...)
+	}
+
+	// Count the matching contacts before paging is applied.
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, contextError(db, err)
+	}
+
+	err := preloadAssociations(base).Select("*, ("+strings.Join(scoreParts, " + ")+") AS score", scoreArgs...).
+		Order("score DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&results).Error
+	if err == nil {
+		err = countNotes(db, searchResultContacts(results)...)
+	}
+	return results, total, contextError(db, err)
+}
+
+// searchResultContacts returns pointers to the contacts of the search results, so that they
+// can be changed in place.
+func searchResultContacts(results []ContactSearchResult) []*models.Contact {
+	contacts := make([]*models.Contact, 0, len(results))
+	for i := range results {
+		contacts = append(contacts, &results[i].Contact)
+	}
+	return contacts
+}
@@ -0,0 +1,82 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// This file defines the ContactThreadRepository interface and its GORM-based implementation
+// for managing the emails of the conversation held with the submitter of a contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"api-contact-form/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContactThreadRepository defines the interface for contact thread data operations.
+type ContactThreadRepository interface {
+	// Create adds a new thread message to the database.
+	Create(ctx context.Context, message *models.ContactThreadMessage) error
+	// UpdateStatus writes the delivery status, error, and sending time of a thread message.
+	UpdateStatus(ctx context.Context, message *models.ContactThreadMessage) error
+	// FindByContact retrieves the thread messages of a contact, oldest first.
+	FindByContact(ctx context.Context, contactID uint) ([]models.ContactThreadMessage, error)
+	// FindByMessageIDs retrieves the thread messages with any of the given Message-IDs, newest first.
+	FindByMessageIDs(ctx context.Context, messageIDs []string) ([]models.ContactThreadMessage, error)
+}
+
+// contactThreadRepository is the GORM-based implementation of ContactThreadRepository.
+// Every operation is bound to the caller's context and bounded by the query timeout.
+type contactThreadRepository struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// NewContactThreadRepository creates a new instance of ContactThreadRepository with the provided GORM DB.
+// Each operation is cancelled once queryTimeout elapses; zero disables the timeout.
+func NewContactThreadRepository(db *gorm.DB, queryTimeout time.Duration) ContactThreadRepository {
+	return &contactThreadRepository{db, queryTimeout}
+}
+
+// Create adds a new thread message to the database.
+// It returns an error if the operation fails.
+func (r *contactThreadRepository) Create(ctx context.Context, message *models.ContactThreadMessage) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	return contextError(db, db.Create(message).Error)
+}
+
+// UpdateStatus writes the delivery status, error, and sending time of a thread message.
+// It returns gorm.ErrRecordNotFound if the message no longer exists, or an error if the operation fails.
+func (r *contactThreadRepository) UpdateStatus(ctx context.Context, message *models.ContactThreadMessage) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Model(message).Select("status", "error", "sent_at").Updates(message)
+	return contextError(db, writeResult(result, gorm.ErrRecordNotFound))
+}
+
+// FindByContact retrieves the thread messages of a contact, oldest first.
+// It returns the thread messages and an error if the operation fails.
+func (r *contactThreadRepository) FindByContact(ctx context.Context, contactID uint) ([]models.ContactThreadMessage, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var messages []models.ContactThreadMessage
+	err := db.Where("contact_id = ?", contactID).Order("created_at ASC, id ASC").Find(&messages).Error
+	return messages, contextError(db, err)
+}
+
+// FindByMessageIDs retrieves the thread messages with any of the given Message-IDs, newest first.
+// It returns the thread messages and an error if the operation fails.
+func (r *contactThreadRepository) FindByMessageIDs(ctx context.Context, messageIDs []string) ([]models.ContactThreadMessage, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var messages []models.ContactThreadMessage
+	err := db.Where("message_id IN ?", uniqueStrings(messageIDs)).Order("created_at DESC, id DESC").Find(&messages).Error
+	return messages, contextError(db, err)
+}
@@ -0,0 +1,96 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// This file defines the FormRepository interface and its GORM-based implementation
+// for managing the forms that contacts are submitted through.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"api-contact-form/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FormRepository defines the interface for form data operations.
+type FormRepository interface {
+	// Create adds a new form to the database. It fails with gorm.ErrDuplicatedKey if the slug is taken.
+	Create(ctx context.Context, form *models.Form) error
+	// FindAll retrieves every form, ordered by slug.
+	FindAll(ctx context.Context) ([]models.Form, error)
+	// FindBySlug retrieves a form by its slug.
+	FindBySlug(ctx context.Context, slug string) (*models.Form, error)
+	// Update modifies the slug, name, description, fields, thank-you URL and translations of an
+	// existing form.
+	// It fails with gorm.ErrDuplicatedKey if the slug is taken.
+	Update(ctx context.Context, form *models.Form) error
+	// Delete permanently removes a form. It fails with gorm.ErrForeignKeyViolated if contacts
+	// were submitted through the form.
+	Delete(ctx context.Context, form *models.Form) error
+}
+
+// formRepository is the GORM-based implementation of FormRepository.
+// Every operation is bound to the caller's context and bounded by the query timeout.
+type formRepository struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// NewFormRepository creates a new instance of FormRepository with the provided GORM DB.
+// Each operation is cancelled once queryTimeout elapses; zero disables the timeout.
+func NewFormRepository(db *gorm.DB, queryTimeout time.Duration) FormRepository {
+	return &formRepository{db, queryTimeout}
+}
+
+// Create adds a new form to the database.
+// It returns gorm.ErrDuplicatedKey if the slug is taken, or an error if the operation fails.
+func (r *formRepository) Create(ctx context.Context, form *models.Form) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(form)
+	return contextError(db, writeResult(result, gorm.ErrDuplicatedKey))
+}
+
+// FindAll retrieves every form from the database, ordered by slug.
+// It returns the forms and an error if the operation fails.
+func (r *formRepository) FindAll(ctx context.Context) ([]models.Form, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var forms []models.Form
+	err := db.Order("slug ASC").Find(&forms).Error
+	return forms, contextError(db, err)
+}
+
+// FindBySlug retrieves a form by its slug.
+// It returns the form and an error if the form is not found or the operation fails.
+func (r *formRepository) FindBySlug(ctx context.Context, slug string) (*models.Form, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var form models.Form
+	err := db.Where("slug = ?", slug).First(&form).Error
+	return &form, contextError(db, err)
+}
+
+// Update writes the slug, name, description, fields, thank-you URL and translations of an existing
+// form to the database.
+// It returns gorm.ErrRecordNotFound if the form no longer exists, or an error if the operation fails.
+func (r *formRepository) Update(ctx context.Context, form *models.Form) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Model(form).Select("slug", "name", "description", "fields", "thank_you_url", "translations").Updates(form)
+	return contextError(db, writeResult(result, gorm.ErrRecordNotFound))
+}
+
+// Delete permanently removes a form from the database. The foreign key of the contact_messages
+// table keeps a form that contacts were submitted through, including deleted contacts.
+// It returns gorm.ErrForeignKeyViolated in that case, or an error if the operation fails.
+func (r *formRepository) Delete(ctx context.Context, form *models.Form) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	return contextError(db, db.Delete(form).Error)
+}
@@ -0,0 +1,105 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// This file defines the IdempotencyRepository interface and its GORM-based implementation,
+// which store the requests sent with an Idempotency-Key header and their responses.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"api-contact-form/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository defines the methods for storing idempotency keys.
+type IdempotencyRepository interface {
+	// Create stores a new idempotency key. It fails with gorm.ErrDuplicatedKey if the key exists.
+	Create(ctx context.Context, key *models.IdempotencyKey) error
+	// FindByKey retrieves an idempotency key by its value.
+	FindByKey(ctx context.Context, key string) (*models.IdempotencyKey, error)
+	// Complete stores the response of the request an idempotency key belongs to.
+	Complete(ctx context.Context, key *models.IdempotencyKey) error
+	// Delete removes an idempotency key, so that the key can be used again.
+	Delete(ctx context.Context, key string) error
+	// DeleteIfExpired removes an idempotency key if it expired before the given time, and
+	// reports whether it was removed.
+	DeleteIfExpired(ctx context.Context, key string, before time.Time) (bool, error)
+	// DeleteExpired removes the idempotency keys that expired before the given time.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// idempotencyRepository is the concrete implementation of IdempotencyRepository using GORM.
+type idempotencyRepository struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository with the provided
+// GORM DB. Each operation is bounded by queryTimeout, unless queryTimeout is zero.
+func NewIdempotencyRepository(db *gorm.DB, queryTimeout time.Duration) IdempotencyRepository {
+	return &idempotencyRepository{db: db, timeout: queryTimeout}
+}
+
+// Create inserts a new idempotency key into the database.
+// It returns gorm.ErrDuplicatedKey if the key already exists, or an error if the operation fails.
+func (r *idempotencyRepository) Create(ctx context.Context, key *models.IdempotencyKey) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	return contextError(db, writeResult(result, gorm.ErrDuplicatedKey))
+}
+
+// FindByKey retrieves an idempotency key by its value.
+// It returns the key and an error if the key is not found or the operation fails.
+func (r *idempotencyRepository) FindByKey(ctx context.Context, key string) (*models.IdempotencyKey, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var record models.IdempotencyKey
+	err := db.Where("idempotency_key = ?", key).First(&record).Error
+	return &record, contextError(db, err)
+}
+
+// Complete writes the stored response of an idempotency key to the database.
+// It returns an error if the operation fails.
+func (r *idempotencyRepository) Complete(ctx context.Context, key *models.IdempotencyKey) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	err := db.Model(key).
+		Select("status_code", "response_headers", "response_body").
+		Updates(key).Error
+	return contextError(db, err)
+}
+
+// Delete removes an idempotency key from the database.
+// It returns an error if the operation fails.
+func (r *idempotencyRepository) Delete(ctx context.Context, key string) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	err := db.Where("idempotency_key = ?", key).Delete(&models.IdempotencyKey{}).Error
+	return contextError(db, err)
+}
+
+// DeleteIfExpired removes an idempotency key from the database if it expired before the given
+// time. The condition on the expiry keeps a key reserved again in the meantime from being removed.
+// It returns whether the key was removed and an error if the operation fails.
+func (r *idempotencyRepository) DeleteIfExpired(ctx context.Context, key string, before time.Time) (bool, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Where("idempotency_key = ? AND expires_at < ?", key, before).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected > 0, contextError(db, result.Error)
+}
+
+// DeleteExpired removes the idempotency keys that expired before the given time.
+// It returns the number of removed keys and an error if the operation fails.
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Where("expires_at < ?", before).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, contextError(db, result.Error)
+}
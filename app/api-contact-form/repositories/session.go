@@ -0,0 +1,40 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// This file provides helpers that bind database operations to a request context,
+// bounded by a configurable per-operation query timeout.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// session returns a GORM DB bound to ctx and the function that releases its resources.
+// When timeout is positive, the operation is cancelled once the timeout elapses.
+func session(ctx context.Context, db *gorm.DB, timeout time.Duration) (*gorm.DB, context.CancelFunc) {
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return db.WithContext(ctx), cancel
+	}
+	return db.WithContext(ctx), func() {}
+}
+
+// contextError makes sure that an error caused by a cancelled or expired context wraps
+// context.Canceled or context.DeadlineExceeded, whatever error the database driver reported.
+func contextError(db *gorm.DB, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := db.Statement.Context.Err(); ctxErr != nil && !errors.Is(err, ctxErr) {
+		return fmt.Errorf("%w: %v", ctxErr, err)
+	}
+	return err
+}
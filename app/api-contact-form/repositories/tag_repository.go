@@ -0,0 +1,93 @@
+// Package repositories provides implementations for data persistence and retrieval
+// related to contact entities in the API Contact Form application.
+//
+// This file defines the TagRepository interface and its GORM-based implementation
+// for managing the tags that categorize contacts.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package repositories
+
+import (
+	"api-contact-form/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository defines the interface for tag data operations.
+type TagRepository interface {
+	// Create adds a new tag to the database. It fails with gorm.ErrDuplicatedKey if the name is taken.
+	Create(ctx context.Context, tag *models.Tag) error
+	// FindAll retrieves every tag, ordered by name.
+	FindAll(ctx context.Context) ([]models.Tag, error)
+	// FindByID retrieves a tag by its ID.
+	FindByID(ctx context.Context, id uint) (*models.Tag, error)
+	// Update modifies the name and color of an existing tag.
+	// It fails with gorm.ErrDuplicatedKey if the name is taken.
+	Update(ctx context.Context, tag *models.Tag) error
+	// Delete permanently removes a tag, detaching it from every contact.
+	Delete(ctx context.Context, tag *models.Tag) error
+}
+
+// tagRepository is the GORM-based implementation of TagRepository.
+// Every operation is bound to the caller's context and bounded by the query timeout.
+type tagRepository struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// NewTagRepository creates a new instance of TagRepository with the provided GORM DB.
+// Each operation is cancelled once queryTimeout elapses; zero disables the timeout.
+func NewTagRepository(db *gorm.DB, queryTimeout time.Duration) TagRepository {
+	return &tagRepository{db, queryTimeout}
+}
+
+// Create adds a new tag to the database.
+// It returns gorm.ErrDuplicatedKey if the name is taken, or an error if the operation fails.
+func (r *tagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(tag)
+	return contextError(db, writeResult(result, gorm.ErrDuplicatedKey))
+}
+
+// FindAll retrieves every tag from the database, ordered by name.
+// It returns the tags and an error if the operation fails.
+func (r *tagRepository) FindAll(ctx context.Context) ([]models.Tag, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var tags []models.Tag
+	err := db.Order("name ASC").Find(&tags).Error
+	return tags, contextError(db, err)
+}
+
+// FindByID retrieves a tag by its ID.
+// It returns the tag and an error if the tag is not found or the operation fails.
+func (r *tagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	var tag models.Tag
+	err := db.Where("id = ?", id).First(&tag).Error
+	return &tag, contextError(db, err)
+}
+
+// Update writes the name and color of an existing tag to the database.
+// It returns gorm.ErrRecordNotFound if the tag no longer exists, or an error if the operation fails.
+func (r *tagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	result := db.Model(tag).Select("name", "color").Updates(tag)
+	return contextError(db, writeResult(result, gorm.ErrRecordNotFound))
+}
+
+// Delete permanently removes a tag from the database. The database detaches it from every
+// contact through the foreign key of the contact_tags table.
+// It returns an error if the operation fails.
+func (r *tagRepository) Delete(ctx context.Context, tag *models.Tag) error {
+	db, cancel := session(ctx, r.db, r.timeout)
+	defer cancel()
+	return contextError(db, db.Delete(tag).Error)
+}
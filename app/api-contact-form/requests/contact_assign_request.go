@@ -0,0 +1,19 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// The ContactAssignRequest struct is used for assigning a contact to a team member
+// and, optionally, routing it to a queue.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// ContactAssignRequest represents the payload for assigning a contact.
+type ContactAssignRequest struct {
+	// Assignee identifies the team member who will handle the contact.
+	// It is required and must not exceed 100 characters.
+	Assignee string `json:"assignee" binding:"required,max=100"`
+
+	// Queue is the queue to route the contact to. When omitted, the contact stays in its
+	// current queue. It must not exceed 50 characters.
+	Queue string `json:"queue" binding:"omitempty,max=50"`
+}
@@ -0,0 +1,67 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// It includes the ContactListQuery struct, which represents the query parameters accepted
+// when listing contact messages through the API.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+import "time"
+
+// ContactListQuery represents the query parameters for listing contact messages.
+type ContactListQuery struct {
+	// Page is the 1-based page number to retrieve.
+	// It defaults to 1 when omitted.
+	Page int `form:"page" binding:"omitempty,min=1"`
+
+	// PerPage is the number of contacts returned per page.
+	// It defaults to 20 when omitted and may not exceed 100.
+	PerPage int `form:"per_page" binding:"omitempty,min=1,max=100"`
+
+	// Name filters contacts whose full name contains the given value.
+	Name string `form:"name" binding:"omitempty,max=100"`
+
+	// Email filters contacts whose email address contains the given value.
+	Email string `form:"email" binding:"omitempty,max=100"`
+
+	// Status filters contacts by status. Repeat the parameter to match any of several statuses,
+	// e.g. status=new&status=read.
+	Status []string `form:"status" binding:"omitempty,max=6,dive,oneof=new read in_progress replied closed spam"`
+
+	// Assignee filters contacts assigned to the given team member.
+	Assignee string `form:"assignee" binding:"omitempty,max=100"`
+
+	// Queue filters contacts routed to the given queue.
+	Queue string `form:"queue" binding:"omitempty,max=50"`
+
+	// Tag filters contacts by tag name. Repeat the parameter to match several tags,
+	// e.g. tag=sales&tag=support.
+	Tag []string `form:"tag" binding:"omitempty,max=20,dive,max=50"`
+
+	// TagMatch selects whether contacts must have "any" (the default) or "all" of the tags.
+	TagMatch string `form:"tag_match" binding:"omitempty,oneof=any all"`
+
+	// CreatedFrom filters contacts created on or after the given date (YYYY-MM-DD).
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02"`
+
+	// CreatedTo filters contacts created on or before the given date (YYYY-MM-DD).
+	CreatedTo *time.Time `form:"created_to" time_format:"2006-01-02"`
+
+	// Sort is the field used to order the results. Prefix it with '-' for descending order.
+	// It defaults to "-created_at" when omitted and is ignored in cursor mode.
+	Sort string `form:"sort" binding:"omitempty,oneof=id -id name -name email -email created_at -created_at updated_at -updated_at"`
+
+	// Pagination selects the paging mode: "offset" (the default) uses page and per_page,
+	// while "cursor" uses keyset pagination ordered by newest first.
+	Pagination string `form:"pagination" binding:"omitempty,oneof=offset cursor"`
+
+	// Cursor is an opaque cursor returned as next_cursor or prev_cursor by a previous
+	// cursor-mode request. Supplying it implies cursor mode.
+	Cursor string `form:"cursor" binding:"omitempty,max=512"`
+}
+
+// IsCursorMode reports whether the query requests keyset (cursor) pagination.
+func (q *ContactListQuery) IsCursorMode() bool {
+	return q.Pagination == "cursor" || q.Cursor != ""
+}
@@ -0,0 +1,14 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// The ContactNoteRequest struct is used for writing or editing an internal note on a contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// ContactNoteRequest represents the payload for writing or editing an internal note.
+type ContactNoteRequest struct {
+	// Body is the content of the note.
+	// It is a required field with a maximum length of 5000 characters.
+	Body string `json:"body" binding:"required,max=5000"`
+}
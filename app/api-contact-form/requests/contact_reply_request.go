@@ -0,0 +1,19 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// The ContactReplyRequest struct is used for replying by email to the submitter of a contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// ContactReplyRequest represents the payload for replying by email to the submitter of a contact.
+type ContactReplyRequest struct {
+	// Subject is the subject of the email.
+	// It is an optional field with a maximum length of 255 characters. When omitted, the reply
+	// takes the subject of the last email of the conversation, prefixed with "Re: ".
+	Subject string `json:"subject" binding:"omitempty,max=255"`
+
+	// Body is the plain-text content of the email.
+	// It is a required field with a maximum length of 20000 characters.
+	Body string `json:"body" binding:"required,max=20000"`
+}
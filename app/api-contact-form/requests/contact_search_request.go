@@ -0,0 +1,23 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// It includes the ContactSearchQuery struct, which represents the query parameters accepted
+// when searching contact messages through the API.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// ContactSearchQuery represents the query parameters for searching contact messages.
+type ContactSearchQuery struct {
+	// Q is the search query matched against the name, email address, and message.
+	// It is a required field with a length between 2 and 200 characters.
+	Q string `form:"q" binding:"required,min=2,max=200"`
+
+	// Page is the 1-based page number to retrieve.
+	// It defaults to 1 when omitted.
+	Page int `form:"page" binding:"omitempty,min=1"`
+
+	// PerPage is the number of results returned per page.
+	// It defaults to 20 when omitted and may not exceed 100.
+	PerPage int `form:"per_page" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,15 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// The ContactTransitionRequest struct is used for changing the status of a contact
+// through the contact handling workflow.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// ContactTransitionRequest represents the payload for changing the status of a contact.
+type ContactTransitionRequest struct {
+	// Status is the status to move the contact to.
+	// It must be one of: new, read, in_progress, replied, closed, spam.
+	Status string `json:"status" binding:"required,oneof=new read in_progress replied closed spam"`
+}
@@ -0,0 +1,23 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// The EmailContactRequest struct holds the data of a contact created from an inbound email.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// EmailContactRequest represents the data of a contact created from an inbound email.
+// Unlike ContactRequest, it has no phone number, which emails do not carry.
+type EmailContactRequest struct {
+	// Name is the name of the sender of the email.
+	// It is a required field with a maximum length of 100 characters.
+	Name string `json:"name" binding:"required,max=100"`
+
+	// Email is the address of the sender of the email.
+	// It is a required field with a maximum length of 100 characters and must follow a valid email format.
+	Email string `json:"email" binding:"required,email,max=100"`
+
+	// Message is the text of the email.
+	// It is a required field.
+	Message string `json:"message" binding:"required"`
+}
@@ -0,0 +1,35 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// The FormContactRequest struct holds the data of a contact submitted through a form other
+// than the default form.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// FormContactRequest represents the data of a contact submitted through a form. The values
+// of the fields named name, email, phone and message fill the matching columns of the contact,
+// and the values of the other fields are kept in FieldValues.
+type FormContactRequest struct {
+	// FormID is the identifier of the form the contact was submitted through.
+	// It is a required field.
+	FormID uint `json:"form_id" binding:"required"`
+
+	// Name is the full name of the person submitting the form.
+	// It is optional, with a maximum length of 100 characters.
+	Name string `json:"name" binding:"max=100"`
+
+	// Email is the email address of the person submitting the form.
+	// It is a required field with a maximum length of 100 characters and must follow a valid email format.
+	Email string `json:"email" binding:"required,email,max=100"`
+
+	// Phone is the phone number of the person submitting the form.
+	// It is optional, with a maximum length of 20 characters.
+	Phone string `json:"phone" binding:"max=20"`
+
+	// Message is the message of the person submitting the form. It is optional.
+	Message string `json:"message"`
+
+	// FieldValues holds the values of the other fields of the form by field name.
+	FieldValues map[string]interface{} `json:"field_values"`
+}
@@ -0,0 +1,102 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// It includes the FormRequest struct for creating or updating forms, with the FormFieldRequest
+// struct of each field of the form and the translations of their texts.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// FormRequest represents the payload for creating or updating a form.
+type FormRequest struct {
+	// Slug is the unique name of the form in URLs, such as "careers". It may contain only
+	// lowercase letters, numbers and single hyphens.
+	// It is a required field with a maximum length of 50 characters.
+	Slug string `json:"slug" binding:"required,max=50"`
+
+	// Name is the display name of the form, such as "Careers".
+	// It is a required field with a maximum length of 100 characters.
+	Name string `json:"name" binding:"required,max=100"`
+
+	// Description explains what the form is used for.
+	// It is optional, with a maximum length of 500 characters.
+	Description string `json:"description" binding:"max=500"`
+
+	// Fields are the fields of the form, in the order they are displayed. At least one and
+	// at most 50 are required, and one of them must be a required field named email of type email.
+	Fields []FormFieldRequest `json:"fields" binding:"required,min=1,max=50,dive"`
+
+	// ThankYouURL is where clients redirect to after a successful submission, either an
+	// absolute http or https URL or a path starting with "/".
+	// It is optional, with a maximum length of 2048 characters.
+	ThankYouURL string `json:"thank_you_url" binding:"max=2048"`
+
+	// Translations holds the name and description of the form in other languages, by
+	// supported language code, such as "id". It is optional.
+	Translations map[string]FormTranslationRequest `json:"translations" binding:"max=10,dive"`
+}
+
+// FormTranslationRequest represents the texts of a form in another language.
+type FormTranslationRequest struct {
+	// Name is the display name of the form.
+	// It is optional, with a maximum length of 100 characters.
+	Name string `json:"name" binding:"max=100"`
+
+	// Description explains what the form is used for.
+	// It is optional, with a maximum length of 500 characters.
+	Description string `json:"description" binding:"max=500"`
+}
+
+// FormFieldRequest represents a field of a form in a FormRequest.
+type FormFieldRequest struct {
+	// Name is the key of the value of the field in submissions, such as "company". It must
+	// start with a lowercase letter and contain only lowercase letters, numbers and underscores.
+	// It is a required field with a maximum length of 50 characters.
+	Name string `json:"name" binding:"required,max=50"`
+
+	// Label is the text displayed next to the field, such as "Company".
+	// It is a required field with a maximum length of 100 characters.
+	Label string `json:"label" binding:"required,max=100"`
+
+	// Placeholder is the hint displayed in the empty field, such as "Acme Inc.".
+	// It is optional, with a maximum length of 255 characters.
+	Placeholder string `json:"placeholder" binding:"max=255"`
+
+	// Type is the type of the field: text, textarea, email, tel, url, number, select,
+	// multiselect, or checkbox. It is a required field.
+	Type string `json:"type" binding:"required,oneof=text textarea email tel url number select multiselect checkbox"`
+
+	// Required reports whether a value must be submitted for the field.
+	Required bool `json:"required"`
+
+	// MaxLength is the maximum number of characters of a text value. It is optional; zero
+	// means the default limit of the type.
+	MaxLength int `json:"max_length" binding:"min=0,max=65535"`
+
+	// Options are the values a select or multiselect field accepts. They are required for
+	// those types, with at most 100 options of up to 100 characters each.
+	Options []string `json:"options" binding:"max=100,dive,required,max=100"`
+
+	// Pattern is a regular expression a text value must match in full, such as "[A-Z]{2}[0-9]{4}".
+	// It is optional, with a maximum length of 255 characters.
+	Pattern string `json:"pattern" binding:"max=255"`
+
+	// Translations holds the texts of the field in other languages, by supported language
+	// code, such as "id". It is optional.
+	Translations map[string]FormFieldTranslationRequest `json:"translations" binding:"max=10,dive"`
+}
+
+// FormFieldTranslationRequest represents the texts of a form field in another language.
+type FormFieldTranslationRequest struct {
+	// Label is the text displayed next to the field.
+	// It is optional, with a maximum length of 100 characters.
+	Label string `json:"label" binding:"max=100"`
+
+	// Placeholder is the hint displayed in the empty field.
+	// It is optional, with a maximum length of 255 characters.
+	Placeholder string `json:"placeholder" binding:"max=255"`
+
+	// Options maps options of a select or multiselect field to the texts displayed for them,
+	// of up to 100 characters each. Texts of values that are not options are ignored.
+	Options map[string]string `json:"options" binding:"max=100,dive,max=100"`
+}
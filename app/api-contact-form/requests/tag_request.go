@@ -0,0 +1,40 @@
+// Package requests defines the request payload structures for the API Contact Form application.
+//
+// It includes the TagRequest struct for creating or updating tags, and the payloads for
+// attaching tags to one or many contacts.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package requests
+
+// TagRequest represents the payload for creating or updating a tag.
+type TagRequest struct {
+	// Name is the name of the tag, such as "sales". It is stored in lower case.
+	// It is a required field with a maximum length of 50 characters.
+	Name string `json:"name" binding:"required,max=50"`
+
+	// Color is the hexadecimal color used to display the tag, such as "#1e90ff".
+	// It is optional.
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+// ContactTagsRequest represents the payload for attaching tags to a contact.
+type ContactTagsRequest struct {
+	// TagIDs lists the IDs of the tags to attach. At least one and at most 50 are required.
+	TagIDs []uint `json:"tag_ids" binding:"required,min=1,max=50,dive,min=1"`
+}
+
+// BulkTagRequest represents the payload for attaching tags to and detaching tags from
+// many contacts at once.
+type BulkTagRequest struct {
+	// ContactIDs lists the IDs of the contacts to change. At least one and at most 500 are required.
+	ContactIDs []uint `json:"contact_ids" binding:"required,min=1,max=500,dive,min=1"`
+
+	// Add lists the IDs of the tags to attach to every contact.
+	// It is required unless Remove is given.
+	Add []uint `json:"add" binding:"required_without=Remove,omitempty,max=50,dive,min=1"`
+
+	// Remove lists the IDs of the tags to detach from every contact.
+	// It is required unless Add is given.
+	Remove []uint `json:"remove" binding:"required_without=Add,omitempty,max=50,dive,min=1"`
+}
@@ -11,8 +11,13 @@ package responses
 import (
 	"api-contact-form/helpers"
 	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"strings"
 )
 
+// snippetRadius is the number of bytes of context kept around the first match in message snippets.
+const snippetRadius = 80
+
 // APIResponse represents the standard structure for API responses.
 type APIResponse struct {
 	// Code is a string representing the status code of the response.
@@ -21,6 +26,44 @@ type APIResponse struct {
 	Message string `json:"message"`
 	// Data holds the payload of the response, which can be any type.
 	Data interface{} `json:"data"`
+	// Meta holds optional metadata about the payload, such as pagination details.
+	Meta interface{} `json:"meta,omitempty"`
+	// Errors lists the invalid fields when the request data failed validation.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a single request field failed validation.
+type FieldError struct {
+	// Field is the name of the field as it appears in the request, such as "email".
+	Field string `json:"field"`
+	// Rule is the validation rule that failed, such as "required" or "max".
+	Rule string `json:"rule"`
+	// Param is the parameter of the rule, such as "100" for max=100, if any.
+	Param string `json:"param"`
+	// Message is a human-readable description of the failure.
+	Message string `json:"message"`
+}
+
+// PaginationMeta represents the paging details of a list response.
+type PaginationMeta struct {
+	// Page is the current 1-based page number.
+	Page int `json:"page"`
+	// PerPage is the maximum number of items per page.
+	PerPage int `json:"per_page"`
+	// Total is the total number of items matching the request.
+	Total int64 `json:"total"`
+	// TotalPages is the total number of pages available.
+	TotalPages int `json:"total_pages"`
+}
+
+// CursorMeta represents the paging details of a list response using keyset pagination.
+type CursorMeta struct {
+	// PerPage is the maximum number of items per page.
+	PerPage int `json:"per_page"`
+	// NextCursor is the opaque cursor of the next page, or null on the last page.
+	NextCursor *string `json:"next_cursor"`
+	// PrevCursor is the opaque cursor of the previous page, or null on the first page.
+	PrevCursor *string `json:"prev_cursor"`
 }
 
 // ContactResponse represents the structure of a contact in API responses.
@@ -39,6 +82,346 @@ type ContactResponse struct {
 	CreatedAt string `json:"created_at"`
 	// UpdatedAt is the timestamp when the contact was last updated, formatted as a human-readable string.
 	UpdatedAt string `json:"updated_at"`
+	// DeletedAt is the timestamp when the contact was moved to the trash, formatted as a human-readable string.
+	// It is omitted for active contacts.
+	DeletedAt string `json:"deleted_at,omitempty"`
+	// Status is the status of the contact in the handling workflow, such as "new" or "replied".
+	Status string `json:"status"`
+	// Assignee identifies the team member handling the contact. It is empty when unassigned.
+	Assignee string `json:"assignee"`
+	// Queue is the queue the contact is routed to. It is empty when not routed to any queue.
+	Queue string `json:"queue"`
+	// Tags are the tags attached to the contact, ordered by name.
+	Tags []TagResponse `json:"tags"`
+	// Attachments are the files attached to the contact, in the order they were uploaded.
+	Attachments []ContactAttachmentResponse `json:"attachments"`
+	// NoteCount is the number of internal notes on the contact.
+	NoteCount int64 `json:"note_count"`
+	// FormID is the identifier of the form the contact was submitted through. It is null for
+	// the default form.
+	FormID *uint `json:"form_id"`
+	// Fields holds the submitted values of the fields of the form that have no column of their
+	// own, by field name. It is empty for the default form.
+	Fields map[string]interface{} `json:"fields"`
+	// Version is incremented on every update. Its quoted value is the ETag expected in the
+	// If-Match header of requests that change the contact.
+	Version uint `json:"version"`
+}
+
+// PublicContactResponse represents a contact in the responses of the public endpoints that
+// create contacts, such as POST /contacts. Unlike ContactResponse, it leaves out how the
+// contact is handled internally: its assignee, its queue and the number of its internal notes.
+type PublicContactResponse struct {
+	// ID is the unique identifier of the contact.
+	ID uint `json:"id"`
+	// Name is the full name of the contact.
+	Name string `json:"name"`
+	// Email is the email address of the contact.
+	Email string `json:"email"`
+	// Phone is the phone number of the contact.
+	Phone string `json:"phone"`
+	// Message is the message content provided by the contact.
+	Message string `json:"message"`
+	// CreatedAt is the timestamp when the contact was created, formatted as a human-readable string.
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt is the timestamp when the contact was last updated, formatted as a human-readable string.
+	UpdatedAt string `json:"updated_at"`
+	// Status is the status of the contact in the handling workflow, such as "new".
+	Status string `json:"status"`
+	// Tags are the tags attached to the contact, ordered by name.
+	Tags []TagResponse `json:"tags"`
+	// Attachments are the files attached to the contact, in the order they were uploaded.
+	Attachments []ContactAttachmentResponse `json:"attachments"`
+	// FormID is the identifier of the form the contact was submitted through. It is null for
+	// the default form.
+	FormID *uint `json:"form_id"`
+	// Fields holds the submitted values of the fields of the form that have no column of their
+	// own, by field name. It is empty for the default form.
+	Fields map[string]interface{} `json:"fields"`
+	// Version is incremented on every update. Its quoted value is the ETag of the contact.
+	Version uint `json:"version"`
+}
+
+// ContactSearchResponse represents a contact matched by a search in API responses.
+type ContactSearchResponse struct {
+	ContactResponse
+	// Score is the relevance of the contact to the search query. Higher is more relevant.
+	Score float64 `json:"score"`
+	// Highlights maps the matched fields (name, email, message) to HTML-escaped snippets
+	// in which the matching terms are wrapped in <mark></mark> tags.
+	Highlights map[string]string `json:"highlights"`
+}
+
+// TagResponse represents the structure of a tag in API responses.
+type TagResponse struct {
+	// ID is the unique identifier of the tag.
+	ID uint `json:"id"`
+	// Name is the name of the tag, in lower case.
+	Name string `json:"name"`
+	// Color is the hexadecimal color used to display the tag. It is empty when the tag has no color.
+	Color string `json:"color"`
+}
+
+// FormResponse represents the structure of a form in API responses.
+type FormResponse struct {
+	// ID is the unique identifier of the form.
+	ID uint `json:"id"`
+	// Slug is the unique name of the form in URLs, such as "careers".
+	Slug string `json:"slug"`
+	// Name is the display name of the form.
+	Name string `json:"name"`
+	// Description explains what the form is used for. It may be empty.
+	Description string `json:"description"`
+	// Fields are the fields of the form, in the order they are displayed.
+	Fields []FormFieldResponse `json:"fields"`
+	// ThankYouURL is where clients redirect to after a successful submission. It may be empty.
+	ThankYouURL string `json:"thank_you_url"`
+	// Translations holds the name and description of the form in other languages, by language code.
+	Translations map[string]models.FormTranslation `json:"translations"`
+	// CreatedAt is the timestamp when the form was created, formatted as a human-readable string.
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt is the timestamp when the form was last updated, formatted as a human-readable string.
+	UpdatedAt string `json:"updated_at"`
+}
+
+// FormFieldResponse represents a field of a form in API responses.
+type FormFieldResponse struct {
+	// Name is the key of the value of the field in submissions.
+	Name string `json:"name"`
+	// Label is the text displayed next to the field.
+	Label string `json:"label"`
+	// Placeholder is the hint displayed in the empty field. It is omitted when empty.
+	Placeholder string `json:"placeholder,omitempty"`
+	// Type is the type of the field, such as "text" or "select".
+	Type string `json:"type"`
+	// Required reports whether a value must be submitted for the field.
+	Required bool `json:"required"`
+	// MaxLength is the maximum number of characters of a text value. It is omitted when the
+	// default limit of the type applies.
+	MaxLength int `json:"max_length,omitempty"`
+	// Options are the values a select or multiselect field accepts. They are omitted for other types.
+	Options []string `json:"options,omitempty"`
+	// Pattern is the regular expression a text value must match in full. It is omitted when empty.
+	Pattern string `json:"pattern,omitempty"`
+	// Translations holds the texts of the field in other languages, by language code. They are
+	// omitted when there are none.
+	Translations map[string]models.FormFieldTranslation `json:"translations,omitempty"`
+}
+
+// FormConfigResponse represents a form as clients render it, with its texts in the language
+// of the request.
+type FormConfigResponse struct {
+	// Slug is the unique name of the form in URLs.
+	Slug string `json:"slug"`
+	// Language is the language of the texts, such as "en".
+	Language string `json:"language"`
+	// Name is the display name of the form.
+	Name string `json:"name"`
+	// Description explains what the form is used for. It may be empty.
+	Description string `json:"description"`
+	// SubmitURL is the path that the values of the form are submitted to.
+	SubmitURL string `json:"submit_url"`
+	// ThankYouURL is where clients redirect to after a successful submission. It may be empty.
+	ThankYouURL string `json:"thank_you_url"`
+	// Fields are the fields of the form, in the order they are displayed.
+	Fields []FormFieldConfigResponse `json:"fields"`
+	// Messages holds the texts of the form itself, by name: submit, optional, success and failure.
+	Messages map[string]string `json:"messages"`
+}
+
+// FormFieldConfigResponse represents a field of a form as clients render it.
+type FormFieldConfigResponse struct {
+	// Name is the key of the value of the field in submissions.
+	Name string `json:"name"`
+	// Label is the text displayed next to the field.
+	Label string `json:"label"`
+	// Placeholder is the hint displayed in the empty field. It may be empty.
+	Placeholder string `json:"placeholder"`
+	// Type is the type of the field, such as "text" or "select".
+	Type string `json:"type"`
+	// Options are the options of a select or multiselect field. They are omitted for other types.
+	Options []FormOptionResponse `json:"options,omitempty"`
+	// Rules are the rules the values of the field must follow.
+	Rules FormFieldRulesResponse `json:"rules"`
+	// Messages holds the error messages displayed when a value breaks a rule, by rule name,
+	// such as "required" or "max". They read like the messages of rejected submissions, but name
+	// the field and its options by their labels.
+	Messages map[string]string `json:"messages"`
+}
+
+// FormOptionResponse represents an option of a select or multiselect field.
+type FormOptionResponse struct {
+	// Value is the value submitted for the option.
+	Value string `json:"value"`
+	// Label is the text displayed for the option.
+	Label string `json:"label"`
+}
+
+// FormFieldRulesResponse represents the rules the values of a form field must follow.
+type FormFieldRulesResponse struct {
+	// Required reports whether a value must be submitted for the field.
+	Required bool `json:"required"`
+	// MaxLength is the maximum number of characters of a text value, including the default
+	// limit of the type. It is omitted for fields that do not hold text.
+	MaxLength int `json:"max_length,omitempty"`
+	// Pattern is the regular expression a text value must match in full. It is omitted when empty.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// ContactAttachmentResponse represents a file attached to a contact in API responses.
+type ContactAttachmentResponse struct {
+	// ID is the unique identifier of the attachment.
+	ID uint `json:"id"`
+	// Filename is the name of the file as uploaded.
+	Filename string `json:"filename"`
+	// ContentType is the MIME type detected from the content of the file.
+	ContentType string `json:"content_type"`
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+	// ScanStatus is the verdict of the malware scan of the file: unscanned, clean, infected, or
+	// failed. Infected files are quarantined and cannot be downloaded.
+	ScanStatus string `json:"scan_status"`
+	// ScanSignature names the malware found in an infected file. It is omitted otherwise.
+	ScanSignature string `json:"scan_signature,omitempty"`
+	// ScannedAt is the timestamp when the file was scanned, formatted as a human-readable string.
+	// It is omitted for files that were not scanned.
+	ScannedAt string `json:"scanned_at,omitempty"`
+	// CreatedAt is the timestamp when the file was attached, formatted as a human-readable string.
+	CreatedAt string `json:"created_at"`
+}
+
+// ContactNoteResponse represents the structure of an internal note in API responses.
+type ContactNoteResponse struct {
+	// ID is the unique identifier of the note.
+	ID uint `json:"id"`
+	// Author identifies the operator who wrote the note.
+	Author string `json:"author"`
+	// Body is the content of the note.
+	Body string `json:"body"`
+	// CreatedAt is the timestamp when the note was created, formatted as a human-readable string.
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt is the timestamp when the note was last edited, formatted as a human-readable string.
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ContactNoteRevisionResponse represents a previous version of a note in API responses.
+type ContactNoteRevisionResponse struct {
+	// ID is the unique identifier of the revision.
+	ID uint `json:"id"`
+	// Body is the content of the note before the edit.
+	Body string `json:"body"`
+	// EditedBy identifies the operator who made the edit.
+	EditedBy string `json:"edited_by"`
+	// EditedAt is the timestamp of the edit, formatted as a human-readable string.
+	EditedAt string `json:"edited_at"`
+}
+
+// ContactThreadMessageResponse represents an email of the conversation with the submitter
+// of a contact in API responses.
+type ContactThreadMessageResponse struct {
+	// ID is the unique identifier of the thread message.
+	ID uint `json:"id"`
+	// Direction is "outbound" for emails sent to the submitter and "inbound" for emails received.
+	Direction string `json:"direction"`
+	// MessageID is the Message-ID header of the email.
+	MessageID string `json:"message_id"`
+	// InReplyTo is the Message-ID of the email this one replies to. It is empty for the first email.
+	InReplyTo string `json:"in_reply_to"`
+	// References lists the Message-IDs of the earlier emails of the conversation, oldest first.
+	References []string `json:"references"`
+	// From is the sender of the email.
+	From string `json:"from"`
+	// To is the recipient of the email.
+	To string `json:"to"`
+	// Subject is the subject of the email.
+	Subject string `json:"subject"`
+	// Body is the plain-text content of the email.
+	Body string `json:"body"`
+	// Actor identifies the operator who sent an outbound email. It is empty when unknown.
+	Actor string `json:"actor"`
+	// Status is the delivery status of the email: pending, sent, failed, or received.
+	Status string `json:"status"`
+	// Error describes why an outbound email could not be sent. It is omitted otherwise.
+	Error string `json:"error,omitempty"`
+	// SentAt is the timestamp when the email was sent or received, formatted as a human-readable
+	// string. It is omitted until then.
+	SentAt string `json:"sent_at,omitempty"`
+	// CreatedAt is the timestamp when the thread message was created, formatted as a human-readable string.
+	CreatedAt string `json:"created_at"`
+}
+
+// ContactStatusTransitionResponse represents a change of the status of a contact in API responses.
+type ContactStatusTransitionResponse struct {
+	// ID is the unique identifier of the status transition.
+	ID uint `json:"id"`
+	// From is the status of the contact before the change.
+	From string `json:"from"`
+	// To is the status of the contact after the change.
+	To string `json:"to"`
+	// Actor identifies who changed the status. It is empty when unknown.
+	Actor string `json:"actor"`
+	// CreatedAt is the timestamp when the status changed, formatted as a human-readable string.
+	CreatedAt string `json:"created_at"`
+}
+
+// ContactAssignmentResponse represents a change of the assignee or queue of a contact in API responses.
+type ContactAssignmentResponse struct {
+	// ID is the unique identifier of the assignment change.
+	ID uint `json:"id"`
+	// FromAssignee is the assignee before the change. It is empty if the contact was unassigned.
+	FromAssignee string `json:"from_assignee"`
+	// ToAssignee is the assignee after the change. It is empty if the contact was unassigned.
+	ToAssignee string `json:"to_assignee"`
+	// FromQueue is the queue before the change.
+	FromQueue string `json:"from_queue"`
+	// ToQueue is the queue after the change.
+	ToQueue string `json:"to_queue"`
+	// Actor identifies who changed the assignment. It is empty for automatic assignments.
+	Actor string `json:"actor"`
+	// CreatedAt is the timestamp when the assignment changed, formatted as a human-readable string.
+	CreatedAt string `json:"created_at"`
+}
+
+// NewPaginationMeta builds a PaginationMeta for the given page, page size, and total item count.
+//
+// Parameters:
+//   - page: The current 1-based page number.
+//   - perPage: The maximum number of items per page.
+//   - total: The total number of items matching the request.
+//
+// Returns:
+//   - A PaginationMeta struct with the total number of pages calculated.
+func NewPaginationMeta(page, perPage int, total int64) PaginationMeta {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = int((total + int64(perPage) - 1) / int64(perPage))
+	}
+	return PaginationMeta{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+// NewCursorMeta builds a CursorMeta for the given page size and cursors.
+//
+// Parameters:
+//   - perPage: The maximum number of items per page.
+//   - next: The encoded cursor of the next page, or an empty string if there is none.
+//   - prev: The encoded cursor of the previous page, or an empty string if there is none.
+//
+// Returns:
+//   - A CursorMeta struct with empty cursors represented as null.
+func NewCursorMeta(perPage int, next, prev string) CursorMeta {
+	meta := CursorMeta{PerPage: perPage}
+	if next != "" {
+		meta.NextCursor = &next
+	}
+	if prev != "" {
+		meta.PrevCursor = &prev
+	}
+	return meta
 }
 
 // ContactResponseFromModel converts a Contact model to a ContactResponse.
@@ -49,13 +432,266 @@ type ContactResponse struct {
 // Returns:
 //   - A ContactResponse struct populated with data from the Contact model.
 func ContactResponseFromModel(contact *models.Contact) ContactResponse {
-	return ContactResponse{
-		ID:        contact.ID,
-		Name:      contact.FullName,
-		Email:     contact.Email,
-		Phone:     contact.Phone,
-		Message:   contact.Message,
-		CreatedAt: helpers.FormatTimeHuman(contact.CreatedAt),
-		UpdatedAt: helpers.FormatTimeHuman(contact.UpdatedAt),
+	response := ContactResponse{
+		ID:          contact.ID,
+		Name:        contact.FullName,
+		Email:       contact.Email,
+		Phone:       contact.Phone,
+		Message:     contact.Message,
+		CreatedAt:   helpers.FormatTimeHuman(contact.CreatedAt),
+		UpdatedAt:   helpers.FormatTimeHuman(contact.UpdatedAt),
+		Status:      contact.Status,
+		Assignee:    contact.Assignee,
+		Queue:       contact.Queue,
+		Tags:        make([]TagResponse, 0, len(contact.Tags)),
+		Attachments: make([]ContactAttachmentResponse, 0, len(contact.Attachments)),
+		NoteCount:   contact.NoteCount,
+		FormID:      contact.FormID,
+		Fields:      contact.FieldValues,
+		Version:     contact.Version,
+	}
+	if response.Fields == nil {
+		response.Fields = map[string]interface{}{}
+	}
+	for _, tag := range contact.Tags {
+		response.Tags = append(response.Tags, TagResponseFromModel(&tag))
+	}
+	for _, attachment := range contact.Attachments {
+		response.Attachments = append(response.Attachments, ContactAttachmentResponseFromModel(&attachment))
+	}
+	if contact.DeletedAt.Valid {
+		response.DeletedAt = helpers.FormatTimeHuman(contact.DeletedAt.Time)
+	}
+	return response
+}
+
+// PublicContactResponseFromModel converts a Contact model to a PublicContactResponse.
+//
+// Parameters:
+//   - contact: A pointer to the Contact model to be converted.
+//
+// Returns:
+//   - A PublicContactResponse struct populated with the public data of the Contact model.
+func PublicContactResponseFromModel(contact *models.Contact) PublicContactResponse {
+	full := ContactResponseFromModel(contact)
+	return PublicContactResponse{
+		ID:          full.ID,
+		Name:        full.Name,
+		Email:       full.Email,
+		Phone:       full.Phone,
+		Message:     full.Message,
+		CreatedAt:   full.CreatedAt,
+		UpdatedAt:   full.UpdatedAt,
+		Status:      full.Status,
+		Tags:        full.Tags,
+		Attachments: full.Attachments,
+		FormID:      full.FormID,
+		Fields:      full.Fields,
+		Version:     full.Version,
+	}
+}
+
+// TagResponseFromModel converts a Tag model to a TagResponse.
+//
+// Parameters:
+//   - tag: A pointer to the Tag model to be converted.
+//
+// Returns:
+//   - A TagResponse struct populated with data from the Tag model.
+func TagResponseFromModel(tag *models.Tag) TagResponse {
+	return TagResponse{
+		ID:    tag.ID,
+		Name:  tag.Name,
+		Color: tag.Color,
+	}
+}
+
+// FormResponseFromModel converts a Form model to a FormResponse.
+//
+// Parameters:
+//   - form: A pointer to the Form model to be converted.
+//
+// Returns:
+//   - A FormResponse struct populated with data from the Form model.
+func FormResponseFromModel(form *models.Form) FormResponse {
+	response := FormResponse{
+		ID:           form.ID,
+		Slug:         form.Slug,
+		Name:         form.Name,
+		Description:  form.Description,
+		Fields:       make([]FormFieldResponse, 0, len(form.Fields)),
+		ThankYouURL:  form.ThankYouURL,
+		Translations: form.Translations,
+		CreatedAt:    helpers.FormatTimeHuman(form.CreatedAt),
+		UpdatedAt:    helpers.FormatTimeHuman(form.UpdatedAt),
+	}
+	if response.Translations == nil {
+		response.Translations = map[string]models.FormTranslation{}
+	}
+	for _, field := range form.Fields {
+		response.Fields = append(response.Fields, FormFieldResponse{
+			Name:         field.Name,
+			Label:        field.Label,
+			Placeholder:  field.Placeholder,
+			Type:         field.Type,
+			Required:     field.Required,
+			MaxLength:    field.MaxLength,
+			Options:      field.Options,
+			Pattern:      field.Pattern,
+			Translations: field.Translations,
+		})
+	}
+	return response
+}
+
+// ContactNoteResponseFromModel converts a ContactNote model to a ContactNoteResponse.
+//
+// Parameters:
+//   - note: A pointer to the ContactNote model to be converted.
+//
+// Returns:
+//   - A ContactNoteResponse struct populated with data from the ContactNote model.
+func ContactNoteResponseFromModel(note *models.ContactNote) ContactNoteResponse {
+	return ContactNoteResponse{
+		ID:        note.ID,
+		Author:    note.Author,
+		Body:      note.Body,
+		CreatedAt: helpers.FormatTimeHuman(note.CreatedAt),
+		UpdatedAt: helpers.FormatTimeHuman(note.UpdatedAt),
+	}
+}
+
+// ContactNoteRevisionResponseFromModel converts a ContactNoteRevision model to a
+// ContactNoteRevisionResponse.
+//
+// Parameters:
+//   - revision: A pointer to the ContactNoteRevision model to be converted.
+//
+// Returns:
+//   - A ContactNoteRevisionResponse struct populated with data from the model.
+func ContactNoteRevisionResponseFromModel(revision *models.ContactNoteRevision) ContactNoteRevisionResponse {
+	return ContactNoteRevisionResponse{
+		ID:       revision.ID,
+		Body:     revision.Body,
+		EditedBy: revision.Editor,
+		EditedAt: helpers.FormatTimeHuman(revision.CreatedAt),
+	}
+}
+
+// ContactAttachmentResponseFromModel converts a ContactAttachment model to a
+// ContactAttachmentResponse.
+//
+// Parameters:
+//   - attachment: A pointer to the ContactAttachment model to be converted.
+//
+// Returns:
+//   - A ContactAttachmentResponse struct populated with data from the model.
+func ContactAttachmentResponseFromModel(attachment *models.ContactAttachment) ContactAttachmentResponse {
+	response := ContactAttachmentResponse{
+		ID:            attachment.ID,
+		Filename:      attachment.Filename,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		ScanStatus:    attachment.ScanStatus,
+		ScanSignature: attachment.ScanSignature,
+		CreatedAt:     helpers.FormatTimeHuman(attachment.CreatedAt),
+	}
+	if attachment.ScannedAt != nil {
+		response.ScannedAt = helpers.FormatTimeHuman(*attachment.ScannedAt)
+	}
+	return response
+}
+
+// ContactThreadMessageResponseFromModel converts a ContactThreadMessage model to a
+// ContactThreadMessageResponse.
+//
+// Parameters:
+//   - message: A pointer to the ContactThreadMessage model to be converted.
+//
+// Returns:
+//   - A ContactThreadMessageResponse struct populated with data from the model.
+func ContactThreadMessageResponseFromModel(message *models.ContactThreadMessage) ContactThreadMessageResponse {
+	response := ContactThreadMessageResponse{
+		ID:         message.ID,
+		Direction:  message.Direction,
+		MessageID:  message.MessageID,
+		InReplyTo:  message.InReplyTo,
+		References: strings.Fields(message.References),
+		From:       message.FromAddress,
+		To:         message.ToAddress,
+		Subject:    message.Subject,
+		Body:       message.Body,
+		Actor:      message.Actor,
+		Status:     message.Status,
+		Error:      message.Error,
+		CreatedAt:  helpers.FormatTimeHuman(message.CreatedAt),
+	}
+	if message.SentAt != nil {
+		response.SentAt = helpers.FormatTimeHuman(*message.SentAt)
+	}
+	return response
+}
+
+// ContactStatusTransitionResponseFromModel converts a ContactStatusTransition model to a
+// ContactStatusTransitionResponse.
+//
+// Parameters:
+//   - transition: A pointer to the ContactStatusTransition model to be converted.
+//
+// Returns:
+//   - A ContactStatusTransitionResponse struct populated with data from the model.
+func ContactStatusTransitionResponseFromModel(transition *models.ContactStatusTransition) ContactStatusTransitionResponse {
+	return ContactStatusTransitionResponse{
+		ID:        transition.ID,
+		From:      transition.FromStatus,
+		To:        transition.ToStatus,
+		Actor:     transition.Actor,
+		CreatedAt: helpers.FormatTimeHuman(transition.CreatedAt),
+	}
+}
+
+// ContactAssignmentResponseFromModel converts a ContactAssignment model to a ContactAssignmentResponse.
+//
+// Parameters:
+//   - assignment: A pointer to the ContactAssignment model to be converted.
+//
+// Returns:
+//   - A ContactAssignmentResponse struct populated with data from the model.
+func ContactAssignmentResponseFromModel(assignment *models.ContactAssignment) ContactAssignmentResponse {
+	return ContactAssignmentResponse{
+		ID:           assignment.ID,
+		FromAssignee: assignment.FromAssignee,
+		ToAssignee:   assignment.ToAssignee,
+		FromQueue:    assignment.FromQueue,
+		ToQueue:      assignment.ToQueue,
+		Actor:        assignment.Actor,
+		CreatedAt:    helpers.FormatTimeHuman(assignment.CreatedAt),
+	}
+}
+
+// ContactSearchResponseFromResult converts a ContactSearchResult to a ContactSearchResponse.
+//
+// Parameters:
+//   - result: A pointer to the ContactSearchResult to be converted.
+//   - terms: The search terms to highlight.
+//
+// Returns:
+//   - A ContactSearchResponse struct populated with the contact, its score, and highlighted snippets.
+func ContactSearchResponseFromResult(result *repositories.ContactSearchResult, terms []string) ContactSearchResponse {
+	highlights := map[string]string{}
+	if snippet := helpers.HighlightSnippet(result.FullName, terms, 0); snippet != "" {
+		highlights["name"] = snippet
+	}
+	if snippet := helpers.HighlightSnippet(result.Email, terms, 0); snippet != "" {
+		highlights["email"] = snippet
+	}
+	if snippet := helpers.HighlightSnippet(result.Message, terms, snippetRadius); snippet != "" {
+		highlights["message"] = snippet
+	}
+
+	return ContactSearchResponse{
+		ContactResponse: ContactResponseFromModel(&result.Contact),
+		Score:           result.Score,
+		Highlights:      highlights,
 	}
 }
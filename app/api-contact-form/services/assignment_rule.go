@@ -0,0 +1,71 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the assignment rules that ContactService applies to every new contact,
+// so that submissions are routed to a queue and assigned to a team member automatically.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"context"
+)
+
+// AssignmentRule routes or assigns a new contact before it is created.
+type AssignmentRule interface {
+	// Apply sets the queue and/or assignee of a new contact. It leaves the contact unchanged
+	// if the rule does not apply to it.
+	Apply(ctx context.Context, contact *models.Contact) error
+}
+
+// queueRule routes contacts that are not in any queue to a default queue.
+type queueRule struct {
+	queue string
+}
+
+// NewQueueRule creates an AssignmentRule that routes contacts that are not in any queue to the given queue.
+func NewQueueRule(queue string) AssignmentRule {
+	return &queueRule{queue}
+}
+
+// Apply routes the contact to the default queue if it is not in any queue.
+func (r *queueRule) Apply(ctx context.Context, contact *models.Contact) error {
+	if contact.Queue == "" {
+		contact.Queue = r.queue
+	}
+	return nil
+}
+
+// roundRobinRule assigns the unassigned contacts of a queue to its members in turn.
+type roundRobinRule struct {
+	repository repositories.ContactRepository
+	queue      string
+	members    []string
+}
+
+// NewRoundRobinRule creates an AssignmentRule that assigns the unassigned contacts of a queue
+// to the given members in turn. The turn is kept in a counter of the queue in the database,
+// so it survives restarts and is shared by every instance of the application.
+func NewRoundRobinRule(repository repositories.ContactRepository, queue string, members []string) AssignmentRule {
+	return &roundRobinRule{repository, queue, members}
+}
+
+// Apply assigns the contact to the member whose turn it is. Each contact takes the next turn
+// of the queue, even when contacts are created concurrently. Contacts of other queues, and
+// contacts that already have an assignee, are left unchanged. A contact that then fails to be
+// created skips its turn.
+func (r *roundRobinRule) Apply(ctx context.Context, contact *models.Contact) error {
+	if contact.Queue != r.queue || contact.Assignee != "" || len(r.members) == 0 {
+		return nil
+	}
+
+	// Take the next turn of the queue, starting with the first member
+	turn, err := r.repository.NextAssignmentTurn(ctx, r.queue)
+	if err != nil {
+		return err
+	}
+	contact.Assignee = r.members[(turn-1)%uint64(len(r.members))]
+	return nil
+}
@@ -0,0 +1,412 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the Attachments struct, which checks the files submitted with contacts
+// against the attachment limits, scans them for malware, and keeps them in the file store,
+// along with the Upload struct describing a submitted file. Files in which malware is found
+// are kept in quarantine, away from the other files, and cannot be downloaded.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/antivirus"
+	"api-contact-form/i18n"
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"api-contact-form/storage"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	// sniffLength is the number of leading bytes of a file used to detect its media type.
+	sniffLength = 3072
+	// maxFilenameLength is the maximum length, in characters, of the name of an attachment.
+	maxFilenameLength = 255
+	// maxScanSignatureLength is the maximum length, in characters, of the name of the malware
+	// found in an attachment.
+	maxScanSignatureLength = 255
+	// attachmentsArea is the area of the file store holding the files attached to contacts.
+	attachmentsArea = "attachments"
+	// quarantineArea is the area of the file store holding the attached files in which malware
+	// was found, so that they can be kept apart, for example with stricter bucket policies.
+	quarantineArea = "quarantine"
+)
+
+// Upload is a file submitted with a contact.
+type Upload struct {
+	// Filename is the name of the file as given by the submitter.
+	Filename string
+	// Size is the length of the file, in bytes.
+	Size int64
+	// Open returns the content of the file. The caller closes it. It may be called more than
+	// once, for example to scan the file before storing it.
+	Open func() (io.ReadCloser, error)
+}
+
+// AttachmentDownload is an attachment ready to be downloaded, either from a URL or from its content.
+type AttachmentDownload struct {
+	// Attachment is the downloaded attachment.
+	Attachment *models.ContactAttachment
+	// URL is a temporary URL the file can be downloaded from, or empty to download Content instead.
+	URL string
+	// Content is the content of the file when URL is empty. The caller closes it.
+	Content io.ReadCloser
+}
+
+// AttachmentLimits restricts the files that can be attached to a contact.
+type AttachmentLimits struct {
+	// MaxFiles is the maximum number of files attached to a contact at once.
+	MaxFiles int
+	// MaxFileSize is the maximum length of each file, in bytes.
+	MaxFileSize int64
+	// AllowedTypes lists the media types files may have, such as "image/png". The type of
+	// a file is sniffed from its content; the type claimed by the submitter is ignored.
+	AllowedTypes []string
+}
+
+// MalwareScanning configures the malware scan of the files attached to contacts.
+type MalwareScanning struct {
+	// Scanner scans files before they are stored. When nil, files are stored unscanned.
+	Scanner antivirus.Scanner
+	// FailOpen stores the files that cannot be scanned, marked as failed, instead of refusing
+	// them with an ErrScanUnavailable error.
+	FailOpen bool
+}
+
+// Attachments keeps the files attached to contacts in the file store.
+type Attachments struct {
+	files      storage.FileStore
+	repository repositories.ContactAttachmentRepository
+	scanning   MalwareScanning
+	limits     AttachmentLimits
+}
+
+// NewAttachments creates a new instance of Attachments that keeps files in the provided
+// FileStore, records them with the ContactAttachmentRepository, scans them as configured,
+// and enforces the limits.
+func NewAttachments(files storage.FileStore, repository repositories.ContactAttachmentRepository, scanning MalwareScanning, limits AttachmentLimits) *Attachments {
+	return &Attachments{
+		files:      files,
+		repository: repository,
+		scanning:   scanning,
+		limits:     limits,
+	}
+}
+
+// Limits returns the limits the attachments are checked against.
+func (a *Attachments) Limits() AttachmentLimits {
+	return a.limits
+}
+
+// store checks the uploads against the limits, scans them, and writes them to the file store.
+// It returns the attachments to record, without their contact, or an ErrInvalidAttachment
+// error if an upload breaks the limits. Nothing is left in the file store on error.
+func (a *Attachments) store(ctx context.Context, uploads []Upload) ([]models.ContactAttachment, error) {
+	if len(uploads) > a.limits.MaxFiles {
+		return nil, &Error{Kind: ErrInvalidAttachment, MessageKey: i18n.MsgTooManyAttachments, Args: []interface{}{a.limits.MaxFiles}}
+	}
+
+	attachments := make([]models.ContactAttachment, 0, len(uploads))
+	for _, upload := range uploads {
+		attachment, err := a.storeOne(ctx, upload)
+		if err != nil {
+			a.remove(ctx, attachments)
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, nil
+}
+
+// storeOne checks an upload against the limits, scans it, and writes it to the file store.
+func (a *Attachments) storeOne(ctx context.Context, upload Upload) (*models.ContactAttachment, error) {
+	filename := attachmentFilename(upload.Filename)
+	if upload.Size > a.limits.MaxFileSize {
+		return nil, errAttachmentTooLarge(filename, a.limits.MaxFileSize)
+	}
+
+	file, err := upload.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Sniff the media type from the leading bytes of the content.
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType, ok := a.allowedType(head)
+	if !ok {
+		return nil, &Error{Kind: ErrInvalidAttachment, MessageKey: i18n.MsgAttachmentTypeNotAllowed, Args: []interface{}{filename}}
+	}
+
+	attachment := &models.ContactAttachment{
+		Filename:    filename,
+		ContentType: contentType,
+		ScanStatus:  models.AttachmentScanUnscanned,
+	}
+	content := io.MultiReader(bytes.NewReader(head), file)
+
+	// Scan the content before it is stored, then read it again to store it.
+	if a.scanning.Scanner != nil {
+		if err := a.scan(ctx, attachment, content); err != nil {
+			return nil, err
+		}
+		again, err := upload.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer again.Close()
+		content = again
+	}
+
+	// Write the content, measuring and hashing it on the way. The content may be longer than
+	// announced, so the limit is enforced while it is written too. Infected files go to quarantine.
+	area := attachmentsArea
+	if attachment.ScanStatus == models.AttachmentScanInfected {
+		area = quarantineArea
+	}
+	key := newStorageKey(area)
+	counter := &countingReader{r: content, limit: a.limits.MaxFileSize}
+	hash := sha256.New()
+	if err := a.files.Put(ctx, key, io.TeeReader(counter, hash), upload.Size, contentType); err != nil {
+		_ = a.files.Delete(context.WithoutCancel(ctx), key)
+		if counter.exceeded {
+			return nil, errAttachmentTooLarge(filename, a.limits.MaxFileSize)
+		}
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	attachment.Size = counter.n
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+	attachment.StorageKey = key
+	return attachment, nil
+}
+
+// scan scans the content of an attachment for malware and records the verdict on the
+// attachment. Files that cannot be scanned are refused with an ErrScanUnavailable error,
+// unless scanning fails open.
+func (a *Attachments) scan(ctx context.Context, attachment *models.ContactAttachment, content io.Reader) error {
+	counter := &countingReader{r: content, limit: a.limits.MaxFileSize}
+	verdict, err := a.scanning.Scanner.Scan(ctx, counter)
+	scannedAt := time.Now()
+	switch {
+	case counter.exceeded:
+		return errAttachmentTooLarge(attachment.Filename, a.limits.MaxFileSize)
+	case err != nil && ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil && a.scanning.FailOpen:
+		log.Printf("Failed to scan attachment %q, storing it unscanned: %v", attachment.Filename, err)
+		attachment.ScanStatus = models.AttachmentScanFailed
+	case err != nil:
+		log.Printf("Failed to scan attachment %q: %v", attachment.Filename, err)
+		return &Error{Kind: ErrScanUnavailable, MessageKey: i18n.MsgScanUnavailable, Args: []interface{}{attachment.Filename}}
+	case verdict.Infected:
+		log.Printf("Quarantined attachment %q: %s found", attachment.Filename, verdict.Signature)
+		attachment.ScanStatus = models.AttachmentScanInfected
+		attachment.ScanSignature = truncate(verdict.Signature, maxScanSignatureLength)
+		attachment.ScannedAt = &scannedAt
+	default:
+		attachment.ScanStatus = models.AttachmentScanClean
+		attachment.ScannedAt = &scannedAt
+	}
+	return nil
+}
+
+// attach stores the uploads and records them as attachments of an existing contact.
+// Nothing is left in the file store on error.
+func (a *Attachments) attach(ctx context.Context, contactID uint, uploads []Upload) ([]models.ContactAttachment, error) {
+	attachments, err := a.store(ctx, uploads)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attachments {
+		attachments[i].ContactID = contactID
+	}
+	if err := a.repository.Create(ctx, attachments); err != nil {
+		a.remove(ctx, attachments)
+		return nil, translateError(err, errContactNotFound)
+	}
+	return attachments, nil
+}
+
+// acceptable returns the uploads that meet the limits, in order, leaving out the others with
+// a log entry. It is used for files that are not worth refusing a submission for, such as the
+// attachments of inbound emails.
+func (a *Attachments) acceptable(uploads []Upload) []Upload {
+	accepted := make([]Upload, 0, len(uploads))
+	for _, upload := range uploads {
+		filename := attachmentFilename(upload.Filename)
+		if len(accepted) == a.limits.MaxFiles {
+			log.Printf("Dropped attachment %q: more than %d files", filename, a.limits.MaxFiles)
+			continue
+		}
+		if upload.Size > a.limits.MaxFileSize {
+			log.Printf("Dropped attachment %q: larger than %s", filename, formatBytes(a.limits.MaxFileSize))
+			continue
+		}
+		head, err := sniff(upload)
+		if err != nil {
+			log.Printf("Dropped attachment %q: %v", filename, err)
+			continue
+		}
+		if contentType, ok := a.allowedType(head); !ok {
+			log.Printf("Dropped attachment %q: %s is not an allowed type", filename, contentType)
+			continue
+		}
+		accepted = append(accepted, upload)
+	}
+	return accepted
+}
+
+// download returns an attachment of a contact along with a signed URL to download it from, or,
+// when the file store does not sign URLs, its content. The caller closes the content.
+func (a *Attachments) download(ctx context.Context, contactID uint, id uint) (*AttachmentDownload, error) {
+	attachment, err := a.repository.FindByID(ctx, contactID, id)
+	if err != nil {
+		return nil, translateError(err, errAttachmentNotFound)
+	}
+	if attachment.ScanStatus == models.AttachmentScanInfected {
+		return nil, errAttachmentQuarantined
+	}
+
+	// Prefer a signed URL, so that the file does not go through the API.
+	if signer, ok := a.files.(storage.URLSigner); ok {
+		url, err := signer.SignedURL(ctx, attachment.StorageKey, attachment.Filename, attachment.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign attachment URL: %w", err)
+		}
+		if url != "" {
+			return &AttachmentDownload{Attachment: attachment, URL: url}, nil
+		}
+	}
+
+	content, err := a.files.Open(ctx, attachment.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, errAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &AttachmentDownload{Attachment: attachment, Content: content}, nil
+}
+
+// remove deletes the files of attachments from the file store. Failures are logged, since the
+// files are no longer referenced and the caller has nothing left to undo.
+func (a *Attachments) remove(ctx context.Context, attachments []models.ContactAttachment) {
+	ctx = context.WithoutCancel(ctx)
+	for _, attachment := range attachments {
+		if err := a.files.Delete(ctx, attachment.StorageKey); err != nil {
+			log.Printf("Failed to delete attachment file %s: %v", attachment.StorageKey, err)
+		}
+	}
+}
+
+// allowedType returns the media type sniffed from the leading bytes of a file, and whether
+// it is one of the allowed types.
+func (a *Attachments) allowedType(head []byte) (string, bool) {
+	detected := mimetype.Detect(head)
+	for _, allowed := range a.limits.AllowedTypes {
+		if detected.Is(allowed) {
+			return detected.String(), true
+		}
+	}
+	return detected.String(), false
+}
+
+// sniff returns the leading bytes of an upload used to detect its media type.
+func sniff(upload Upload) ([]byte, error) {
+	file, err := upload.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return head[:n], nil
+}
+
+// countingReader counts the bytes read through it and fails once more than limit bytes are read.
+type countingReader struct {
+	r        io.Reader
+	n        int64
+	limit    int64
+	exceeded bool
+}
+
+// Read reads from the underlying reader, failing once the limit is exceeded.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if c.n > c.limit {
+		c.exceeded = true
+		return n, errors.New("attachment exceeds the maximum size")
+	}
+	return n, err
+}
+
+// errAttachmentTooLarge returns the error of a file larger than the maximum size.
+func errAttachmentTooLarge(filename string, maxSize int64) error {
+	return &Error{Kind: ErrInvalidAttachment, MessageKey: i18n.MsgAttachmentTooLarge, Args: []interface{}{filename, formatBytes(maxSize)}}
+}
+
+// attachmentFilename returns the base name of a submitted filename, cut to the maximum length.
+// Files without a usable name are called "attachment".
+func attachmentFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, filename)
+	if filename == "" || filename == "." || filename == "/" {
+		filename = "attachment"
+	}
+	return truncate(filename, maxFilenameLength)
+}
+
+// newStorageKey returns a new, unique key for an attachment file in an area of the file store,
+// such as "attachments/2024/05/3f9a...".
+func newStorageKey(area string) string {
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+	return area + "/" + time.Now().UTC().Format("2006/01") + "/" + hex.EncodeToString(random)
+}
+
+// formatBytes formats a number of bytes for humans, such as "10 MB".
+func formatBytes(n int64) string {
+	const unit = 1 << 10
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + " " + suffix
+}
@@ -0,0 +1,197 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file tests the malware scan of the files attached to contacts against a fake clamd:
+// where the scanned files are stored, the scan status recorded on them, and how files that
+// cannot be scanned are handled when scanning fails open or closed.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/antivirus"
+	"api-contact-form/antivirus/antivirustest"
+	"api-contact-form/models"
+	"api-contact-form/storage"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Replies of the fake clamd.
+const (
+	clamdClean    = "stream: OK"
+	clamdInfected = "stream: Win.Test.EICAR_HDB-1 FOUND"
+	clamdError    = "stream: Can't allocate memory ERROR"
+)
+
+// newScanningAttachments creates Attachments that keep text files in a local file store and
+// scan them with clamd at the given address. It returns the Attachments and the root of the store.
+func newScanningAttachments(t *testing.T, address string, failOpen bool) (*Attachments, string) {
+	t.Helper()
+
+	root := t.TempDir()
+	files, err := storage.NewLocalStore(root)
+	if err != nil {
+		t.Fatalf("failed to create file store: %v", err)
+	}
+	scanner, err := antivirus.NewClamdScanner(antivirus.ClamdConfig{Address: address, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("failed to create scanner: %v", err)
+	}
+	limits := AttachmentLimits{MaxFiles: 5, MaxFileSize: 1 << 20, AllowedTypes: []string{"text/plain"}}
+	return NewAttachments(files, nil, MalwareScanning{Scanner: scanner, FailOpen: failOpen}, limits), root
+}
+
+// textUpload returns an upload of a text file with the given content.
+func textUpload(filename string, content []byte) Upload {
+	return Upload{
+		Filename: filename,
+		Size:     int64(len(content)),
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(content)), nil
+		},
+	}
+}
+
+// storedFiles returns the keys of the files kept under the root of a local file store.
+func storedFiles(t *testing.T, root string) []string {
+	t.Helper()
+	var keys []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		key, err := filepath.Rel(root, path)
+		keys = append(keys, filepath.ToSlash(key))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to list stored files: %v", err)
+	}
+	return keys
+}
+
+// TestAttachmentsScan checks the scan status and the area of the file store of the attached
+// files for each verdict of clamd, with scanning failing open and closed.
+func TestAttachmentsScan(t *testing.T) {
+	tests := []struct {
+		name          string
+		reply         string
+		failOpen      bool
+		wantStatus    string
+		wantSignature string
+		wantArea      string
+		wantScannedAt bool
+	}{
+		{
+			name:          "clean",
+			reply:         clamdClean,
+			wantStatus:    models.AttachmentScanClean,
+			wantArea:      attachmentsArea,
+			wantScannedAt: true,
+		},
+		{
+			name:          "infected",
+			reply:         clamdInfected,
+			wantStatus:    models.AttachmentScanInfected,
+			wantSignature: "Win.Test.EICAR_HDB-1",
+			wantArea:      quarantineArea,
+			wantScannedAt: true,
+		},
+		{
+			name:       "error failing open",
+			reply:      clamdError,
+			failOpen:   true,
+			wantStatus: models.AttachmentScanFailed,
+			wantArea:   attachmentsArea,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clamd := antivirustest.NewClamd(t, func([]byte) string { return tt.reply })
+			attachments, root := newScanningAttachments(t, clamd.Address, tt.failOpen)
+			content := []byte("Please find my question attached.\n")
+
+			stored, err := attachments.store(context.Background(), []Upload{textUpload("question.txt", content)})
+			if err != nil {
+				t.Fatalf("store() error = %v", err)
+			}
+			if len(stored) != 1 {
+				t.Fatalf("store() returned %d attachments, want 1", len(stored))
+			}
+			attachment := stored[0]
+
+			// Verdict recorded on the attachment
+			if attachment.ScanStatus != tt.wantStatus {
+				t.Errorf("scan status = %q, want %q", attachment.ScanStatus, tt.wantStatus)
+			}
+			if attachment.ScanSignature != tt.wantSignature {
+				t.Errorf("scan signature = %q, want %q", attachment.ScanSignature, tt.wantSignature)
+			}
+			if (attachment.ScannedAt != nil) != tt.wantScannedAt {
+				t.Errorf("scanned at = %v, want set %v", attachment.ScannedAt, tt.wantScannedAt)
+			}
+
+			// Content scanned, then stored in the expected area
+			if scanned := clamd.Scanned(); len(scanned) != 1 || !bytes.Equal(scanned[0], content) {
+				t.Errorf("clamd received %d scans, want the content once", len(scanned))
+			}
+			if !strings.HasPrefix(attachment.StorageKey, tt.wantArea+"/") {
+				t.Errorf("storage key = %q, want it in the %q area", attachment.StorageKey, tt.wantArea)
+			}
+			if keys := storedFiles(t, root); len(keys) != 1 || keys[0] != attachment.StorageKey {
+				t.Errorf("stored files = %v, want [%s]", keys, attachment.StorageKey)
+			}
+			file, err := attachments.files.Open(context.Background(), attachment.StorageKey)
+			if err != nil {
+				t.Fatalf("failed to open stored file: %v", err)
+			}
+			defer file.Close()
+			if got, err := io.ReadAll(file); err != nil || !bytes.Equal(got, content) {
+				t.Errorf("stored content = %q (%v), want %q", got, err, content)
+			}
+		})
+	}
+}
+
+// TestAttachmentsScanFailingClosed checks that files clamd cannot scan, or that cannot be
+// scanned because clamd is unreachable, are refused with an ErrScanUnavailable error and
+// nothing is left in the file store.
+func TestAttachmentsScanFailingClosed(t *testing.T) {
+	tests := []struct {
+		name        string
+		unreachable bool
+	}{
+		{name: "error"},
+		{name: "unreachable", unreachable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clamd := antivirustest.NewClamd(t, func([]byte) string { return clamdError })
+			if tt.unreachable {
+				clamd.Close()
+			}
+			attachments, root := newScanningAttachments(t, clamd.Address, false)
+
+			stored, err := attachments.store(context.Background(), []Upload{textUpload("question.txt", []byte("Please find my question attached.\n"))})
+			if !errors.Is(err, ErrScanUnavailable) {
+				t.Fatalf("store() error = %v, want %v", err, ErrScanUnavailable)
+			}
+			if stored != nil {
+				t.Errorf("store() attachments = %+v, want nil", stored)
+			}
+			if keys := storedFiles(t, root); len(keys) != 0 {
+				t.Errorf("stored files = %v, want none", keys)
+			}
+		})
+	}
+}
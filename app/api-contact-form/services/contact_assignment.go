@@ -0,0 +1,95 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file implements the ContactService methods that assign contacts to team members,
+// route them to queues, and report their assignment history.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/models"
+	"api-contact-form/requests"
+	"context"
+)
+
+// AssignContact assigns an existing contact identified by its ID to a team member.
+// It validates the request, retrieves the contact, and checks its version. If the request
+// names a queue, the contact is routed to it as well; otherwise it stays in its queue.
+// The change is persisted together with a record of it, unless nothing changes.
+// Returns the updated Contact and any error encountered.
+func (s *contactService) AssignContact(ctx context.Context, id uint, version uint, req *requests.ContactAssignRequest, actor string) (*models.Contact, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Retrieve the existing contact and check its version
+	contact, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	if err := checkVersion(contact, version); err != nil {
+		return nil, err
+	}
+
+	// Keep the contact in its queue unless the request names another one
+	queue := req.Queue
+	if queue == "" {
+		queue = contact.Queue
+	}
+	return s.changeAssignment(ctx, contact, req.Assignee, queue, actor)
+}
+
+// UnassignContact removes the assignee of an existing contact identified by its ID.
+// It retrieves the contact and checks its version. The contact stays in its queue.
+// The change is persisted together with a record of it, unless the contact is already unassigned.
+// Returns the updated Contact and any error encountered.
+func (s *contactService) UnassignContact(ctx context.Context, id uint, version uint, actor string) (*models.Contact, error) {
+	// Retrieve the existing contact and check its version
+	contact, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	if err := checkVersion(contact, version); err != nil {
+		return nil, err
+	}
+
+	return s.changeAssignment(ctx, contact, "", contact.Queue, actor)
+}
+
+// GetContactAssignments retrieves the assignment history of an existing contact identified by its ID.
+// Returns the assignment changes, oldest first, and any error encountered.
+func (s *contactService) GetContactAssignments(ctx context.Context, id uint) ([]models.ContactAssignment, error) {
+	// Make sure the contact exists
+	if _, err := s.repository.FindByID(ctx, id); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	assignments, err := s.repository.FindAssignments(ctx, id)
+	return assignments, translateError(err, errContactNotFound)
+}
+
+// changeAssignment sets the assignee and queue of a contact and persists the change together
+// with a record of it. A contact whose assignee and queue do not change is returned as is.
+func (s *contactService) changeAssignment(ctx context.Context, contact *models.Contact, assignee, queue, actor string) (*models.Contact, error) {
+	if contact.Assignee == assignee && contact.Queue == queue {
+		return contact, nil
+	}
+
+	assignment := &models.ContactAssignment{
+		ContactID:    contact.ID,
+		FromAssignee: contact.Assignee,
+		ToAssignee:   assignee,
+		FromQueue:    contact.Queue,
+		ToQueue:      queue,
+		Actor:        actor,
+	}
+	contact.Assignee = assignee
+	contact.Queue = queue
+
+	if err := s.repository.UpdateAssignment(ctx, contact, assignment); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	return contact, nil
+}
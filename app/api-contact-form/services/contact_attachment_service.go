@@ -0,0 +1,48 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the ContactAttachmentService interface and its implementation, which give
+// access to the files attached to contacts.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/repositories"
+	"context"
+)
+
+// ContactAttachmentService defines the interface for reading the files attached to contacts.
+type ContactAttachmentService interface {
+	// DownloadAttachment retrieves an attachment of a contact along with a temporary URL to
+	// download it from or, when the file store does not provide one, its content, which the
+	// caller must close. It fails with an ErrNotFound error if the contact does not exist or
+	// is deleted, or if the attachment does not exist on the contact.
+	DownloadAttachment(ctx context.Context, contactID uint, id uint) (*AttachmentDownload, error)
+}
+
+// contactAttachmentService is the concrete implementation of ContactAttachmentService.
+type contactAttachmentService struct {
+	contacts    repositories.ContactRepository
+	attachments *Attachments
+}
+
+// NewContactAttachmentService creates a new instance of ContactAttachmentService with the
+// provided ContactRepository and Attachments.
+func NewContactAttachmentService(contacts repositories.ContactRepository, attachments *Attachments) ContactAttachmentService {
+	return &contactAttachmentService{
+		contacts:    contacts,
+		attachments: attachments,
+	}
+}
+
+// DownloadAttachment retrieves an attachment of an existing contact, ready to be downloaded.
+// Returns the download and any error encountered.
+func (s *contactAttachmentService) DownloadAttachment(ctx context.Context, contactID uint, id uint) (*AttachmentDownload, error) {
+	// Make sure the contact exists
+	if _, err := s.contacts.FindByID(ctx, contactID); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	return s.attachments.download(ctx, contactID, id)
+}
@@ -0,0 +1,161 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the ContactNoteService interface and its implementation, which manage the
+// internal notes operators leave on contacts, along with the edit history of each note.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"api-contact-form/requests"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ContactNoteService defines the interface for contact note business logic operations.
+// Every operation fails with an ErrNotFound error if the contact does not exist or is deleted.
+type ContactNoteService interface {
+	// CreateNote writes a new note on a contact on behalf of the author.
+	CreateNote(ctx context.Context, contactID uint, req *requests.ContactNoteRequest, author string) (*models.ContactNote, error)
+	// GetNotes retrieves the notes of a contact, oldest first.
+	GetNotes(ctx context.Context, contactID uint) ([]models.ContactNote, error)
+	// UpdateNote edits a note of a contact on behalf of the editor, keeping the previous body
+	// in the edit history.
+	UpdateNote(ctx context.Context, contactID uint, id uint, req *requests.ContactNoteRequest, editor string) (*models.ContactNote, error)
+	// DeleteNote removes a note of a contact along with its edit history.
+	DeleteNote(ctx context.Context, contactID uint, id uint) error
+	// GetNoteRevisions retrieves the edit history of a note of a contact, oldest first.
+	GetNoteRevisions(ctx context.Context, contactID uint, id uint) ([]models.ContactNoteRevision, error)
+}
+
+// contactNoteService is the concrete implementation of ContactNoteService.
+type contactNoteService struct {
+	contacts repositories.ContactRepository
+	notes    repositories.ContactNoteRepository
+	validate *validator.Validate
+}
+
+// NewContactNoteService creates a new instance of ContactNoteService with the provided
+// ContactRepository, ContactNoteRepository, and the validator used for request validation.
+func NewContactNoteService(contacts repositories.ContactRepository, notes repositories.ContactNoteRepository, validate *validator.Validate) ContactNoteService {
+	return &contactNoteService{
+		contacts: contacts,
+		notes:    notes,
+		validate: validate,
+	}
+}
+
+// CreateNote writes a new note on an existing contact.
+// It validates the request, makes sure the contact exists, and persists the note.
+// Returns the created ContactNote and any error encountered.
+func (s *contactNoteService) CreateNote(ctx context.Context, contactID uint, req *requests.ContactNoteRequest, author string) (*models.ContactNote, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Make sure the contact exists
+	if _, err := s.contacts.FindByID(ctx, contactID); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	note := models.ContactNote{
+		ContactID: contactID,
+		Author:    author,
+		Body:      req.Body,
+	}
+
+	err := s.notes.Create(ctx, &note)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	return &note, nil
+}
+
+// GetNotes retrieves the notes of an existing contact, oldest first.
+// Returns the notes and any error encountered.
+func (s *contactNoteService) GetNotes(ctx context.Context, contactID uint) ([]models.ContactNote, error) {
+	// Make sure the contact exists
+	if _, err := s.contacts.FindByID(ctx, contactID); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	notes, err := s.notes.FindByContact(ctx, contactID)
+	return notes, translateError(err, errNoteNotFound)
+}
+
+// UpdateNote edits a note of an existing contact.
+// It validates the request, retrieves the note, and persists the new body together with a
+// revision holding the previous one. A body that does not change is not recorded.
+// Returns the updated ContactNote and any error encountered.
+func (s *contactNoteService) UpdateNote(ctx context.Context, contactID uint, id uint, req *requests.ContactNoteRequest, editor string) (*models.ContactNote, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Retrieve the existing note
+	note, err := s.findNote(ctx, contactID, id)
+	if err != nil {
+		return nil, err
+	}
+	if note.Body == req.Body {
+		return note, nil
+	}
+
+	// Keep the previous body in the edit history
+	revision := &models.ContactNoteRevision{
+		NoteID: note.ID,
+		Body:   note.Body,
+		Editor: editor,
+	}
+	note.Body = req.Body
+
+	if err := s.notes.Update(ctx, note, revision); err != nil {
+		return nil, translateError(err, errNoteNotFound)
+	}
+	return note, nil
+}
+
+// DeleteNote removes a note of an existing contact along with its edit history.
+// Returns any error encountered.
+func (s *contactNoteService) DeleteNote(ctx context.Context, contactID uint, id uint) error {
+	// Retrieve the existing note
+	note, err := s.findNote(ctx, contactID, id)
+	if err != nil {
+		return err
+	}
+
+	return translateError(s.notes.Delete(ctx, note), errNoteNotFound)
+}
+
+// GetNoteRevisions retrieves the edit history of a note of an existing contact, oldest first.
+// Returns the revisions and any error encountered.
+func (s *contactNoteService) GetNoteRevisions(ctx context.Context, contactID uint, id uint) ([]models.ContactNoteRevision, error) {
+	// Retrieve the existing note
+	if _, err := s.findNote(ctx, contactID, id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.notes.FindRevisions(ctx, id)
+	return revisions, translateError(err, errNoteNotFound)
+}
+
+// findNote retrieves a note of an existing contact, translating missing records into
+// errContactNotFound or errNoteNotFound.
+func (s *contactNoteService) findNote(ctx context.Context, contactID uint, id uint) (*models.ContactNote, error) {
+	// Make sure the contact exists
+	if _, err := s.contacts.FindByID(ctx, contactID); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	note, err := s.notes.FindByID(ctx, contactID, id)
+	if err != nil {
+		return nil, translateError(err, errNoteNotFound)
+	}
+	return note, nil
+}
@@ -0,0 +1,196 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the ContactReplyService interface and its implementation, which reply by
+// email to the submitter of a contact and keep every email of the conversation in a thread.
+// Replies carry the Message-ID, In-Reply-To, and References headers so that mail clients
+// show the whole conversation together.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/mailer"
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"api-contact-form/requests"
+	"context"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// replySubjectPrefix is prepended to the subject of the email a reply answers.
+	replySubjectPrefix = "Re: "
+	// maxSubjectLength is the maximum length, in characters, of the subject of a thread message.
+	maxSubjectLength = 255
+)
+
+// ContactReplyService defines the interface for replying by email to the submitter of a contact.
+// Every operation fails with an ErrNotFound error if the contact does not exist or is deleted.
+type ContactReplyService interface {
+	// SendReply emails a reply to the submitter of a contact on behalf of the actor and adds
+	// it to the thread of the contact. It fails with ErrMailUnavailable if no mail server is
+	// configured, and with ErrMailDelivery if the mail server does not accept the email, in
+	// which case the reply stays in the thread as failed.
+	SendReply(ctx context.Context, contactID uint, req *requests.ContactReplyRequest, actor string) (*models.ContactThreadMessage, error)
+	// GetThread retrieves the emails exchanged with the submitter of a contact, oldest first.
+	GetThread(ctx context.Context, contactID uint) ([]models.ContactThreadMessage, error)
+}
+
+// contactReplyService is the concrete implementation of ContactReplyService.
+type contactReplyService struct {
+	contacts       repositories.ContactRepository
+	threads        repositories.ContactThreadRepository
+	mailer         mailer.Mailer
+	validate       *validator.Validate
+	defaultSubject string
+	mailbox        string
+}
+
+// NewContactReplyService creates a new instance of ContactReplyService with the provided
+// ContactRepository, ContactThreadRepository, Mailer, and the validator used for request
+// validation. A nil Mailer disables sending replies. defaultSubject is the subject of the
+// first reply to a contact when the request does not set one. When mailbox, the address of
+// the contact mailbox, is set, replies ask to be answered at its plus-addressed variant that
+// routes answers back to the thread of the contact.
+func NewContactReplyService(contacts repositories.ContactRepository, threads repositories.ContactThreadRepository, sender mailer.Mailer, validate *validator.Validate, defaultSubject string, mailbox string) ContactReplyService {
+	return &contactReplyService{
+		contacts:       contacts,
+		threads:        threads,
+		mailer:         sender,
+		validate:       validate,
+		defaultSubject: defaultSubject,
+		mailbox:        mailbox,
+	}
+}
+
+// SendReply emails a reply to the submitter of an existing contact.
+// It validates the request, threads the reply after the last email of the conversation,
+// records it as pending, sends it, and records whether the mail server accepted it.
+// Returns the reply and any error encountered.
+func (s *contactReplyService) SendReply(ctx context.Context, contactID uint, req *requests.ContactReplyRequest, actor string) (*models.ContactThreadMessage, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+	if s.mailer == nil {
+		return nil, errMailUnavailable
+	}
+
+	// Retrieve the contact and its thread
+	contact, err := s.contacts.FindByID(ctx, contactID)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	thread, err := s.threads.FindByContact(ctx, contactID)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	// Build the reply, threaded after the last email the submitter may have seen
+	from := s.mailer.From()
+	to := &mail.Address{Name: contact.FullName, Address: contact.Email}
+	msg := &mailer.Message{
+		From:      from,
+		To:        []*mail.Address{to},
+		Subject:   s.replySubject(req.Subject, thread),
+		Body:      req.Body,
+		MessageID: mailer.NewMessageID(messageIDDomain(from)),
+	}
+	if s.mailbox != "" {
+		msg.ReplyTo = &mail.Address{Name: from.Name, Address: contactThreadAddress(s.mailbox, contactID)}
+	}
+	if parent := lastDeliveredMessage(thread); parent != nil {
+		msg.InReplyTo = parent.MessageID
+		msg.References = append(strings.Fields(parent.References), parent.MessageID)
+	}
+
+	reply := models.ContactThreadMessage{
+		ContactID:   contactID,
+		Direction:   models.ThreadDirectionOutbound,
+		MessageID:   msg.MessageID,
+		InReplyTo:   msg.InReplyTo,
+		References:  strings.Join(msg.References, " "),
+		FromAddress: from.String(),
+		ToAddress:   to.String(),
+		Subject:     msg.Subject,
+		Body:        msg.Body,
+		Actor:       actor,
+		Status:      models.ThreadStatusPending,
+	}
+	if err := s.threads.Create(ctx, &reply); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	// Send the reply. Once started, the delivery and its outcome are not abandoned when the
+	// client goes away, so that the thread reflects what the submitter received.
+	ctx = context.WithoutCancel(ctx)
+	sendErr := s.mailer.Send(ctx, msg)
+	if sendErr != nil {
+		reply.Status = models.ThreadStatusFailed
+		reply.Error = sendErr.Error()
+	} else {
+		now := time.Now()
+		reply.Status = models.ThreadStatusSent
+		reply.SentAt = &now
+	}
+	if err := s.threads.UpdateStatus(ctx, &reply); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	if sendErr != nil {
+		return nil, errMailDelivery
+	}
+	return &reply, nil
+}
+
+// GetThread retrieves the emails exchanged with the submitter of an existing contact, oldest first.
+// Returns the thread messages and any error encountered.
+func (s *contactReplyService) GetThread(ctx context.Context, contactID uint) ([]models.ContactThreadMessage, error) {
+	// Make sure the contact exists
+	if _, err := s.contacts.FindByID(ctx, contactID); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	thread, err := s.threads.FindByContact(ctx, contactID)
+	return thread, translateError(err, errContactNotFound)
+}
+
+// replySubject returns the subject of a reply: the requested one if any, with its whitespace
+// collapsed so that it fits on a header line, otherwise the subject of the last email of the
+// thread prefixed with "Re: ", otherwise the default subject.
+func (s *contactReplyService) replySubject(subject string, thread []models.ContactThreadMessage) string {
+	if subject = strings.Join(strings.Fields(subject), " "); subject != "" {
+		return subject
+	}
+	if len(thread) == 0 {
+		return s.defaultSubject
+	}
+	last := thread[len(thread)-1].Subject
+	if !strings.HasPrefix(strings.ToLower(last), strings.ToLower(replySubjectPrefix)) {
+		last = replySubjectPrefix + last
+	}
+	return truncate(last, maxSubjectLength)
+}
+
+// lastDeliveredMessage returns the last email of a thread that was received or sent, skipping
+// replies that never reached the submitter. It returns nil if there is none.
+func lastDeliveredMessage(thread []models.ContactThreadMessage) *models.ContactThreadMessage {
+	for i := len(thread) - 1; i >= 0; i-- {
+		if thread[i].Status != models.ThreadStatusPending && thread[i].Status != models.ThreadStatusFailed {
+			return &thread[i]
+		}
+	}
+	return nil
+}
+
+// messageIDDomain returns the domain of the sender address, used to generate Message-IDs.
+func messageIDDomain(from *mail.Address) string {
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 {
+		return from.Address[at+1:]
+	}
+	return "localhost"
+}
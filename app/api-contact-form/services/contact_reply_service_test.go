@@ -0,0 +1,355 @@
+// Package services_test tests the business logic of the API Contact Form application.
+//
+// This file tests ContactReplyService against an in-process fake SMTP server and a migrated
+// SQLite database, checking the envelope and threading headers of the emails it sends and
+// the delivery status recorded in the thread.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services_test
+
+import (
+	"api-contact-form/mailer"
+	"api-contact-form/migrations"
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"api-contact-form/requests"
+	"api-contact-form/services"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/mail"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-smtp"
+	"github.com/glebarez/sqlite"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// receivedEmail is an email accepted by the fake SMTP server.
+type receivedEmail struct {
+	from       string
+	recipients []string
+	data       []byte
+}
+
+// fakeSMTPServer is an in-process SMTP server that records the emails it accepts.
+type fakeSMTPServer struct {
+	port int
+
+	mu         sync.Mutex
+	emails     []receivedEmail
+	deliveries int
+	reject     bool
+	onData     func()
+}
+
+// startFakeSMTPServer starts a fake SMTP server on a random local port. It is closed when
+// the test ends.
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	fake := &fakeSMTPServer{port: listener.Addr().(*net.TCPAddr).Port}
+	server := smtp.NewServer(smtp.BackendFunc(func(_ *smtp.Conn) (smtp.Session, error) {
+		return &fakeSMTPSession{server: fake}, nil
+	}))
+	server.Domain = "localhost"
+	server.ReadTimeout = 5 * time.Second
+	server.WriteTimeout = 5 * time.Second
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+	return fake
+}
+
+// configure sets whether the server rejects the emails it receives, and a function called
+// while an email is being received, before it is accepted or rejected.
+func (s *fakeSMTPServer) configure(reject bool, onData func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reject = reject
+	s.onData = onData
+}
+
+// attempts returns how many emails the server has received, accepted or not.
+func (s *fakeSMTPServer) attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deliveries
+}
+
+// accepted returns the emails the server has accepted, oldest first.
+func (s *fakeSMTPServer) accepted() []receivedEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedEmail(nil), s.emails...)
+}
+
+// fakeSMTPSession receives the emails of a connection to the fake SMTP server.
+type fakeSMTPSession struct {
+	server     *fakeSMTPServer
+	from       string
+	recipients []string
+}
+
+// Mail records the sender of the email.
+func (s *fakeSMTPSession) Mail(from string, _ *smtp.MailOptions) error {
+	s.from = from
+	return nil
+}
+
+// Rcpt records a recipient of the email.
+func (s *fakeSMTPSession) Rcpt(to string, _ *smtp.RcptOptions) error {
+	s.recipients = append(s.recipients, to)
+	return nil
+}
+
+// Data reads the email, then accepts or rejects it as configured.
+func (s *fakeSMTPSession) Data(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.server.mu.Lock()
+	s.server.deliveries++
+	reject, onData := s.server.reject, s.server.onData
+	s.server.mu.Unlock()
+	if onData != nil {
+		onData()
+	}
+	if reject {
+		return &smtp.SMTPError{
+			Code:         554,
+			EnhancedCode: smtp.EnhancedCode{5, 7, 1},
+			Message:      "Message rejected",
+		}
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+	s.server.emails = append(s.server.emails, receivedEmail{from: s.from, recipients: s.recipients, data: data})
+	return nil
+}
+
+// Reset discards the email being received.
+func (s *fakeSMTPSession) Reset() {
+	s.from = ""
+	s.recipients = nil
+}
+
+// Logout ends the session.
+func (s *fakeSMTPSession) Logout() error {
+	return nil
+}
+
+// replyFixture holds the service under test and its dependencies.
+type replyFixture struct {
+	service services.ContactReplyService
+	threads repositories.ContactThreadRepository
+	server  *fakeSMTPServer
+	contact *models.Contact
+}
+
+// newReplyFixture creates a ContactReplyService that sends through a fake SMTP server and
+// stores its threads in a fresh SQLite database holding a single contact.
+func newReplyFixture(t *testing.T) *replyFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	// Create and migrate the database
+	dsn := filepath.Join(t.TempDir(), "contacts.sqlite") + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	contacts := repositories.NewContactRepository(db, 5*time.Second)
+	threads := repositories.NewContactThreadRepository(db, 5*time.Second)
+	contact := &models.Contact{
+		FullName: "Jane Doe",
+		Email:    "jane@example.org",
+		Phone:    "+6281234567890",
+		Message:  "Hello, I have a question.",
+	}
+	if err := contacts.Create(ctx, contact); err != nil {
+		t.Fatalf("failed to create contact: %v", err)
+	}
+
+	// Send through the fake SMTP server
+	server := startFakeSMTPServer(t)
+	sender, err := mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:    "127.0.0.1",
+		Port:    server.port,
+		TLS:     mailer.TLSNone,
+		From:    &mail.Address{Name: "Support", Address: "support@example.com"},
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed to create mailer: %v", err)
+	}
+
+	validate := validator.New()
+	validate.SetTagName("binding")
+	service := services.NewContactReplyService(contacts, threads, sender, validate, "Re: Your message", "")
+	return &replyFixture{service: service, threads: threads, server: server, contact: contact}
+}
+
+// expectPendingDuringDelivery makes the fake SMTP server check, while it receives an email,
+// that the last message of the thread is recorded as pending.
+func (f *replyFixture) expectPendingDuringDelivery(t *testing.T, reject bool) {
+	t.Helper()
+	f.server.configure(reject, func() {
+		thread, err := f.threads.FindByContact(context.Background(), f.contact.ID)
+		if err != nil {
+			t.Errorf("failed to read thread during delivery: %v", err)
+			return
+		}
+		if len(thread) == 0 {
+			t.Error("thread is empty during delivery")
+			return
+		}
+		if status := thread[len(thread)-1].Status; status != models.ThreadStatusPending {
+			t.Errorf("status during delivery = %q, want %q", status, models.ThreadStatusPending)
+		}
+	})
+}
+
+// storedMessage returns the thread message with the given ID as stored in the database.
+func (f *replyFixture) storedMessage(t *testing.T, id uint) models.ContactThreadMessage {
+	t.Helper()
+	thread, err := f.threads.FindByContact(context.Background(), f.contact.ID)
+	if err != nil {
+		t.Fatalf("failed to read thread: %v", err)
+	}
+	for _, message := range thread {
+		if message.ID == id {
+			return message
+		}
+	}
+	t.Fatalf("thread message %d not found", id)
+	return models.ContactThreadMessage{}
+}
+
+// TestSendReplyDeliversThreadedReplies checks that replies reach the submitter, are recorded
+// as pending and then sent, and that a second reply is threaded after the first.
+func TestSendReplyDeliversThreadedReplies(t *testing.T) {
+	f := newReplyFixture(t)
+	ctx := context.Background()
+
+	// First reply
+	f.expectPendingDuringDelivery(t, false)
+	first, err := f.service.SendReply(ctx, f.contact.ID, &requests.ContactReplyRequest{Body: "Thanks for writing."}, "agent")
+	if err != nil {
+		t.Fatalf("first SendReply() error = %v", err)
+	}
+	if stored := f.storedMessage(t, first.ID); stored.Status != models.ThreadStatusSent || stored.SentAt == nil {
+		t.Errorf("first reply status = %q, sent at %v, want %q with a time", stored.Status, stored.SentAt, models.ThreadStatusSent)
+	}
+
+	// Second reply
+	second, err := f.service.SendReply(ctx, f.contact.ID, &requests.ContactReplyRequest{Body: "Any news?"}, "agent")
+	if err != nil {
+		t.Fatalf("second SendReply() error = %v", err)
+	}
+	if stored := f.storedMessage(t, second.ID); stored.Status != models.ThreadStatusSent {
+		t.Errorf("second reply status = %q, want %q", stored.Status, models.ThreadStatusSent)
+	}
+
+	if attempts := f.server.attempts(); attempts != 2 {
+		t.Fatalf("server received %d emails, want 2", attempts)
+	}
+	emails := f.server.accepted()
+	if len(emails) != 2 {
+		t.Fatalf("server accepted %d emails, want 2", len(emails))
+	}
+
+	// Envelope of both replies
+	for i, email := range emails {
+		if email.from != "support@example.com" {
+			t.Errorf("email %d sender = %q, want %q", i, email.from, "support@example.com")
+		}
+		if len(email.recipients) != 1 || email.recipients[0] != f.contact.Email {
+			t.Errorf("email %d recipients = %v, want [%s]", i, email.recipients, f.contact.Email)
+		}
+	}
+
+	// Threading headers of the second reply
+	msg, err := mail.ReadMessage(bytes.NewReader(emails[1].data))
+	if err != nil {
+		t.Fatalf("failed to parse second email: %v", err)
+	}
+	headers := map[string]string{
+		"Message-ID":  second.MessageID,
+		"In-Reply-To": first.MessageID,
+		"References":  first.MessageID,
+	}
+	for name, want := range headers {
+		if got := msg.Header.Get(name); got != want {
+			t.Errorf("second email %s = %q, want %q", name, got, want)
+		}
+	}
+	if to, err := msg.Header.AddressList("To"); err != nil || len(to) != 1 || to[0].Address != f.contact.Email {
+		t.Errorf("second email To = %v (%v), want %s", to, err, f.contact.Email)
+	}
+}
+
+// TestSendReplyRecordsRejectedReplies checks that a reply the mail server rejects is recorded
+// as pending and then failed, and that SendReply reports the failed delivery.
+func TestSendReplyRecordsRejectedReplies(t *testing.T) {
+	f := newReplyFixture(t)
+	ctx := context.Background()
+
+	f.expectPendingDuringDelivery(t, true)
+	reply, err := f.service.SendReply(ctx, f.contact.ID, &requests.ContactReplyRequest{Body: "Thanks for writing."}, "agent")
+	if !errors.Is(err, services.ErrMailDelivery) {
+		t.Fatalf("SendReply() error = %v, want %v", err, services.ErrMailDelivery)
+	}
+	if reply != nil {
+		t.Errorf("SendReply() reply = %+v, want nil", reply)
+	}
+	if attempts := f.server.attempts(); attempts != 1 {
+		t.Errorf("server received %d emails, want 1", attempts)
+	}
+	if emails := f.server.accepted(); len(emails) != 0 {
+		t.Errorf("server accepted %d emails, want 0", len(emails))
+	}
+
+	thread, err := f.threads.FindByContact(ctx, f.contact.ID)
+	if err != nil {
+		t.Fatalf("failed to read thread: %v", err)
+	}
+	if len(thread) != 1 {
+		t.Fatalf("thread has %d messages, want 1", len(thread))
+	}
+	if stored := thread[0]; stored.Status != models.ThreadStatusFailed || stored.Error == "" || stored.SentAt != nil {
+		t.Errorf("reply status = %q, error %q, sent at %v, want %q with an error and no time", stored.Status, stored.Error, stored.SentAt, models.ThreadStatusFailed)
+	}
+}
@@ -9,51 +9,119 @@
 package services
 
 import (
+	"api-contact-form/helpers"
 	"api-contact-form/models"
 	"api-contact-form/repositories"
 	"api-contact-form/requests"
+	"context"
+	"encoding/json"
 
 	"github.com/go-playground/validator/v10"
 )
 
+const (
+	// defaultPerPage is the page size used when the client does not request one.
+	defaultPerPage = 20
+	// defaultSort is the sort order used when the client does not request one.
+	defaultSort = "-created_at"
+)
+
 // ContactService defines the business logic interface for contact operations.
 type ContactService interface {
-	// CreateContact creates a new contact based on the provided request.
-	CreateContact(req *requests.ContactRequest) (*models.Contact, error)
-	// GetAllContacts retrieves all non-deleted contacts.
-	GetAllContacts() ([]models.Contact, error)
+	// CreateContact creates a new contact based on the provided request, with the uploaded
+	// files attached.
+	CreateContact(ctx context.Context, req *requests.ContactRequest, uploads ...Upload) (*models.Contact, error)
+	// CreateEmailContact creates a new contact from the sender and text of an inbound email,
+	// together with the thread message recording the email, with the uploaded files attached.
+	CreateEmailContact(ctx context.Context, req *requests.EmailContactRequest, message *models.ContactThreadMessage, uploads ...Upload) (*models.Contact, error)
+	// CreateFormContact creates a new contact from the values submitted through a form other
+	// than the default form.
+	CreateFormContact(ctx context.Context, req *requests.FormContactRequest) (*models.Contact, error)
+	// GetAllContacts retrieves a page of non-deleted contacts matching the query,
+	// along with the total number of matching contacts.
+	GetAllContacts(ctx context.Context, query *requests.ContactListQuery) ([]models.Contact, int64, error)
+	// GetContactsByCursor retrieves a page of non-deleted contacts matching the query
+	// using keyset pagination.
+	GetContactsByCursor(ctx context.Context, query *requests.ContactListQuery) (*repositories.ContactCursorPage, error)
+	// SearchContacts retrieves a page of non-deleted contacts matching the search query,
+	// ranked by relevance, along with the total number of matching contacts.
+	SearchContacts(ctx context.Context, query *requests.ContactSearchQuery) ([]repositories.ContactSearchResult, int64, error)
 	// GetContactByID retrieves a single contact by its ID.
-	GetContactByID(id uint) (*models.Contact, error)
-	// UpdateContact updates an existing contact identified by its ID.
-	UpdateContact(id uint, req *requests.ContactRequest) (*models.Contact, error)
-	// DeleteContact marks a contact as deleted based on its ID.
-	DeleteContact(id uint) error
+	GetContactByID(ctx context.Context, id uint) (*models.Contact, error)
+	// UpdateContact updates an existing contact identified by its ID, provided that it still
+	// has the expected version. A version of 0 matches any version.
+	UpdateContact(ctx context.Context, id uint, version uint, req *requests.ContactRequest) (*models.Contact, error)
+	// PatchContact partially updates an existing contact identified by its ID by applying
+	// a JSON Merge Patch (RFC 7396) to its request representation, provided that it still
+	// has the expected version. A version of 0 matches any version.
+	PatchContact(ctx context.Context, id uint, version uint, patch map[string]interface{}) (*models.Contact, error)
+	// DeleteContact marks a contact as deleted based on its ID, provided that it still has
+	// the expected version. A version of 0 matches any version.
+	DeleteContact(ctx context.Context, id uint, version uint) error
+	// GetTrashedContacts retrieves a page of deleted contacts matching the query,
+	// along with the total number of matching contacts.
+	GetTrashedContacts(ctx context.Context, query *requests.ContactListQuery) ([]models.Contact, int64, error)
+	// RestoreContact moves a deleted contact identified by its ID out of the trash.
+	RestoreContact(ctx context.Context, id uint) (*models.Contact, error)
+	// PurgeContact permanently removes a contact identified by its ID, whether it is deleted or not,
+	// provided that it still has the expected version. A version of 0 matches any version.
+	PurgeContact(ctx context.Context, id uint, version uint) error
+	// TransitionContact moves a contact identified by its ID to another status of the workflow,
+	// recording who made the change, provided that it still has the expected version.
+	// A version of 0 matches any version.
+	TransitionContact(ctx context.Context, id uint, version uint, req *requests.ContactTransitionRequest, actor string) (*models.Contact, error)
+	// GetContactTransitions retrieves the status history of a contact identified by its ID.
+	GetContactTransitions(ctx context.Context, id uint) ([]models.ContactStatusTransition, error)
+	// AssignContact assigns a contact identified by its ID to a team member and, optionally,
+	// routes it to another queue, recording who made the change, provided that it still has
+	// the expected version. A version of 0 matches any version.
+	AssignContact(ctx context.Context, id uint, version uint, req *requests.ContactAssignRequest, actor string) (*models.Contact, error)
+	// UnassignContact removes the assignee of a contact identified by its ID, keeping its queue,
+	// provided that it still has the expected version. A version of 0 matches any version.
+	UnassignContact(ctx context.Context, id uint, version uint, actor string) (*models.Contact, error)
+	// GetContactAssignments retrieves the assignment history of a contact identified by its ID.
+	GetContactAssignments(ctx context.Context, id uint) ([]models.ContactAssignment, error)
+	// AddContactTags attaches tags to a contact identified by its ID.
+	AddContactTags(ctx context.Context, id uint, req *requests.ContactTagsRequest) (*models.Contact, error)
+	// RemoveContactTag detaches a tag from a contact identified by its ID.
+	RemoveContactTag(ctx context.Context, id uint, tagID uint) (*models.Contact, error)
+	// BulkTagContacts attaches tags to and detaches tags from many contacts at once.
+	BulkTagContacts(ctx context.Context, req *requests.BulkTagRequest) ([]models.Contact, error)
 }
 
 // contactService is the concrete implementation of ContactService.
-// It interacts with the ContactRepository to perform data operations and uses
-// a validator to ensure request data integrity.
+// It interacts with the ContactRepository and ContactSearcher to perform data operations,
+// keeps the files attached to contacts with Attachments, uses a validator to ensure request
+// data integrity, and applies the assignment rules to new contacts.
 type contactService struct {
-	repository repositories.ContactRepository
-	validate   *validator.Validate
+	repository  repositories.ContactRepository
+	searcher    repositories.ContactSearcher
+	attachments *Attachments
+	validate    *validator.Validate
+	rules       []AssignmentRule
 }
 
-// NewContactService creates a new instance of ContactService with the provided ContactRepository.
-// It initializes the validator for request validation.
-func NewContactService(repository repositories.ContactRepository) ContactService {
+// NewContactService creates a new instance of ContactService with the provided ContactRepository,
+// ContactSearcher, Attachments, the validator used for request validation, and the assignment
+// rules applied, in order, to every new contact.
+func NewContactService(repository repositories.ContactRepository, searcher repositories.ContactSearcher, attachments *Attachments, validate *validator.Validate, rules ...AssignmentRule) ContactService {
 	return &contactService{
-		repository: repository,
-		validate:   validator.New(),
+		repository:  repository,
+		searcher:    searcher,
+		attachments: attachments,
+		validate:    validate,
+		rules:       rules,
 	}
 }
 
 // CreateContact creates a new contact based on the provided ContactRequest.
-// It validates the request, maps it to the Contact model, and persists it using the repository.
+// It validates the request, maps it to the Contact model, applies the assignment rules,
+// stores the uploaded files, and persists the contact with its attachments using the repository.
 // Returns the created Contact and any error encountered.
-func (s *contactService) CreateContact(req *requests.ContactRequest) (*models.Contact, error) {
+func (s *contactService) CreateContact(ctx context.Context, req *requests.ContactRequest, uploads ...Upload) (*models.Contact, error) {
 	// Validate input
 	if err := s.validate.Struct(req); err != nil {
-		return nil, err
+		return nil, &ValidationError{Err: err}
 	}
 
 	// Map request to Contact model
@@ -62,37 +130,220 @@ func (s *contactService) CreateContact(req *requests.ContactRequest) (*models.Co
 		Email:    req.Email,
 		Phone:    req.Phone,
 		Message:  req.Message,
+		Status:   models.ContactStatusNew,
+	}
+	return s.createContact(ctx, &contact, uploads)
+}
+
+// CreateEmailContact creates a new contact from an inbound email based on the provided
+// EmailContactRequest. The contact has no phone number.
+// It validates the request, maps it to the Contact model, applies the assignment rules,
+// stores the uploaded files, and persists the contact with its attachments and the thread
+// message recording the email in a single transaction using the repository, so that a
+// redelivered email finds the contact it created. The ID and contact ID of the message are set.
+// Returns the created Contact and any error encountered.
+func (s *contactService) CreateEmailContact(ctx context.Context, req *requests.EmailContactRequest, message *models.ContactThreadMessage, uploads ...Upload) (*models.Contact, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Map request to Contact model
+	contact := models.Contact{
+		FullName: req.Name,
+		Email:    req.Email,
+		Message:  req.Message,
+		Status:   models.ContactStatusNew,
+	}
+	contact.ThreadMessages = []models.ContactThreadMessage{*message}
+	if _, err := s.createContact(ctx, &contact, uploads); err != nil {
+		return nil, err
+	}
+	*message = contact.ThreadMessages[0]
+	contact.ThreadMessages = nil
+	return &contact, nil
+}
+
+// CreateFormContact creates a new contact submitted through a form based on the provided
+// FormContactRequest. The values of the fields without a column of their own are kept with the contact.
+// It validates the request, maps it to the Contact model, applies the assignment rules,
+// and persists the contact using the repository.
+// Returns the created Contact and any error encountered.
+func (s *contactService) CreateFormContact(ctx context.Context, req *requests.FormContactRequest) (*models.Contact, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Map request to Contact model
+	formID := req.FormID
+	contact := models.Contact{
+		FullName:    req.Name,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Message:     req.Message,
+		Status:      models.ContactStatusNew,
+		FormID:      &formID,
+		FieldValues: req.FieldValues,
+	}
+	return s.createContact(ctx, &contact, nil)
+}
+
+// createContact applies the assignment rules to a new contact, stores the uploaded files, and
+// persists the contact with its attachments using the repository. The stored files are removed
+// again if the contact cannot be persisted.
+// Returns the created Contact and any error encountered.
+func (s *contactService) createContact(ctx context.Context, contact *models.Contact, uploads []Upload) (*models.Contact, error) {
+	// Route and assign the contact
+	for _, rule := range s.rules {
+		if err := rule.Apply(ctx, contact); err != nil {
+			return nil, translateError(err, errContactNotFound)
+		}
+	}
+
+	// Store the uploaded files
+	if len(uploads) > 0 {
+		attachments, err := s.attachments.store(ctx, uploads)
+		if err != nil {
+			return nil, err
+		}
+		contact.Attachments = attachments
 	}
 
 	// Persist the contact using the repository
-	err := s.repository.Create(&contact)
-	return &contact, err
+	if err := s.repository.Create(ctx, contact); err != nil {
+		s.attachments.remove(ctx, contact.Attachments)
+		return nil, translateError(err, errContactNotFound)
+	}
+	return contact, nil
+}
+
+// GetAllContacts retrieves a page of non-deleted contacts matching the query from the repository.
+// Missing paging and sorting values in the query are replaced with their defaults so that
+// callers can use them to describe the returned page.
+// Returns a slice of Contact models, the total number of matching contacts, and any error encountered.
+func (s *contactService) GetAllContacts(ctx context.Context, query *requests.ContactListQuery) ([]models.Contact, int64, error) {
+	contacts, total, err := s.repository.FindAll(ctx, pagedContactFilterFromQuery(query))
+	return contacts, total, translateError(err, errContactNotFound)
 }
 
-// GetAllContacts retrieves all non-deleted contacts from the repository.
-// Returns a slice of Contact models and any error encountered.
-func (s *contactService) GetAllContacts() ([]models.Contact, error) {
-	return s.repository.FindAll()
+// GetTrashedContacts retrieves a page of deleted contacts matching the query from the repository.
+// Missing paging and sorting values in the query are replaced with their defaults.
+// Returns a slice of Contact models, the total number of matching contacts, and any error encountered.
+func (s *contactService) GetTrashedContacts(ctx context.Context, query *requests.ContactListQuery) ([]models.Contact, int64, error) {
+	contacts, total, err := s.repository.FindTrashed(ctx, pagedContactFilterFromQuery(query))
+	return contacts, total, translateError(err, errTrashedContactNotFound)
+}
+
+// GetContactsByCursor retrieves a page of non-deleted contacts using keyset pagination.
+// A missing page size in the query is replaced with its default. An empty cursor starts
+// at the newest contact.
+// Returns the page of contacts with its cursors and any error encountered, including
+// ErrInvalidCursor if the cursor cannot be decoded.
+func (s *contactService) GetContactsByCursor(ctx context.Context, query *requests.ContactListQuery) (*repositories.ContactCursorPage, error) {
+	// Apply default page size
+	if query.PerPage < 1 {
+		query.PerPage = defaultPerPage
+	}
+
+	// Decode the opaque cursor, if any
+	var cursor *repositories.ContactCursor
+	if query.Cursor != "" {
+		decoded, err := repositories.DecodeContactCursor(query.Cursor)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		cursor = decoded
+	}
+
+	page, err := s.repository.FindByCursor(ctx, contactFilterFromQuery(query), cursor, query.PerPage)
+	return page, translateError(err, errContactNotFound)
+}
+
+// SearchContacts retrieves a page of non-deleted contacts matching the search query using the searcher.
+// Missing paging values in the query are replaced with their defaults.
+// Returns the matching contacts ranked by relevance, the total number of matches, and any error encountered.
+func (s *contactService) SearchContacts(ctx context.Context, query *requests.ContactSearchQuery) ([]repositories.ContactSearchResult, int64, error) {
+	// Apply default paging values
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PerPage < 1 {
+		query.PerPage = defaultPerPage
+	}
+
+	results, total, err := s.searcher.Search(ctx, query.Q, query.PerPage, (query.Page-1)*query.PerPage)
+	return results, total, translateError(err, errContactNotFound)
+}
+
+// pagedContactFilterFromQuery applies default paging and sorting values to the query and
+// maps it to a repository filter for offset pagination.
+func pagedContactFilterFromQuery(query *requests.ContactListQuery) repositories.ContactFilter {
+	// Apply default paging and sorting values
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PerPage < 1 {
+		query.PerPage = defaultPerPage
+	}
+	if query.Sort == "" {
+		query.Sort = defaultSort
+	}
+
+	// Map query to repository filter
+	filter := contactFilterFromQuery(query)
+	filter.Sort = query.Sort
+	filter.Limit = query.PerPage
+	filter.Offset = (query.Page - 1) * query.PerPage
+	return filter
+}
+
+// contactFilterFromQuery maps the filter fields of a ContactListQuery to a repository filter.
+func contactFilterFromQuery(query *requests.ContactListQuery) repositories.ContactFilter {
+	filter := repositories.ContactFilter{
+		Name:         query.Name,
+		Email:        query.Email,
+		Statuses:     query.Status,
+		Assignee:     query.Assignee,
+		Queue:        query.Queue,
+		Tags:         normalizeTagNames(query.Tag),
+		MatchAllTags: query.TagMatch == "all",
+		CreatedFrom:  query.CreatedFrom,
+	}
+	if query.CreatedTo != nil {
+		// Include the whole day of the upper bound
+		createdTo := query.CreatedTo.AddDate(0, 0, 1)
+		filter.CreatedTo = &createdTo
+	}
+	return filter
 }
 
 // GetContactByID retrieves a single contact by its ID.
-// Returns the Contact model and any error encountered if the contact is not found.
-func (s *contactService) GetContactByID(id uint) (*models.Contact, error) {
-	return s.repository.FindByID(id)
+// Returns the Contact model and any error encountered, including ErrNotFound if the contact is not found.
+func (s *contactService) GetContactByID(ctx context.Context, id uint) (*models.Contact, error) {
+	contact, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	return contact, nil
 }
 
 // UpdateContact updates an existing contact identified by its ID based on the provided ContactRequest.
-// It validates the request, retrieves the existing contact, updates its fields, and persists the changes.
+// It validates the request, retrieves the existing contact, checks its version, updates its fields,
+// and persists the changes.
 // Returns the updated Contact and any error encountered.
-func (s *contactService) UpdateContact(id uint, req *requests.ContactRequest) (*models.Contact, error) {
+func (s *contactService) UpdateContact(ctx context.Context, id uint, version uint, req *requests.ContactRequest) (*models.Contact, error) {
 	// Validate input
 	if err := s.validate.Struct(req); err != nil {
-		return nil, err
+		return nil, &ValidationError{Err: err}
 	}
 
-	// Retrieve the existing contact
-	contact, err := s.repository.FindByID(id)
+	// Retrieve the existing contact and check its version
+	contact, err := s.repository.FindByID(ctx, id)
 	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	if err := checkVersion(contact, version); err != nil {
 		return nil, err
 	}
 
@@ -103,20 +354,155 @@ func (s *contactService) UpdateContact(id uint, req *requests.ContactRequest) (*
 	contact.Message = req.Message
 
 	// Persist the updated contact using the repository
-	err = s.repository.Update(contact)
-	return contact, err
+	err = s.repository.Update(ctx, contact)
+	return contact, translateError(err, errContactNotFound)
 }
 
-// DeleteContact marks a contact as deleted based on its ID.
-// It retrieves the contact and sets its DeletedAt field to the current time.
+// PatchContact partially updates an existing contact identified by its ID.
+// It applies the JSON Merge Patch to the ContactRequest representation of the contact,
+// validates only the fields present in the patch, and persists only the columns that changed,
+// leaving the other columns, including created_at, untouched.
+// Returns the updated Contact and any error encountered.
+func (s *contactService) PatchContact(ctx context.Context, id uint, version uint, patch map[string]interface{}) (*models.Contact, error) {
+	// Retrieve the existing contact and check its version
+	contact, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	if err := checkVersion(contact, version); err != nil {
+		return nil, err
+	}
+
+	// Apply the patch to the request representation of the contact
+	req, err := patchContactRequest(contact, patch)
+	if err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Validate only the fields present in the patch
+	fields := helpers.PatchedFields(req, patch)
+	if len(fields) == 0 {
+		return contact, nil
+	}
+	if err := s.validate.StructPartial(req, fields...); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Update the contact fields that changed
+	var columns []string
+	if req.Name != contact.FullName {
+		contact.FullName = req.Name
+		columns = append(columns, "full_name")
+	}
+	if req.Email != contact.Email {
+		contact.Email = req.Email
+		columns = append(columns, "email_address")
+	}
+	if req.Phone != contact.Phone {
+		contact.Phone = req.Phone
+		columns = append(columns, "phone_number")
+	}
+	if req.Message != contact.Message {
+		contact.Message = req.Message
+		columns = append(columns, "message_text")
+	}
+	if len(columns) == 0 {
+		return contact, nil
+	}
+
+	// Persist only the changed columns using the repository
+	err = s.repository.Update(ctx, contact, columns...)
+	return contact, translateError(err, errContactNotFound)
+}
+
+// DeleteContact marks a contact as deleted based on its ID, moving it to the trash.
+// It retrieves the contact, checks its version, and sets its DeletedAt field to the current time.
 // Returns any error encountered during the operation.
-func (s *contactService) DeleteContact(id uint) error {
-	// Retrieve the contact to be deleted
-	contact, err := s.repository.FindByID(id)
+func (s *contactService) DeleteContact(ctx context.Context, id uint, version uint) error {
+	// Retrieve the contact to be deleted and check its version
+	contact, err := s.repository.FindByID(ctx, id)
 	if err != nil {
+		return translateError(err, errContactNotFound)
+	}
+	if err := checkVersion(contact, version); err != nil {
 		return err
 	}
 
 	// Mark the contact as deleted
-	return s.repository.Delete(contact)
+	return translateError(s.repository.Delete(ctx, contact), errContactNotFound)
+}
+
+// RestoreContact moves a deleted contact out of the trash based on its ID.
+// It retrieves the contact from the trash and clears its DeletedAt field.
+// Returns the restored Contact and any error encountered if the contact is not in the trash.
+func (s *contactService) RestoreContact(ctx context.Context, id uint) (*models.Contact, error) {
+	// Retrieve the contact from the trash
+	contact, err := s.repository.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errTrashedContactNotFound)
+	}
+
+	// Clear the deleted mark
+	err = s.repository.Restore(ctx, contact)
+	return contact, translateError(err, errTrashedContactNotFound)
+}
+
+// PurgeContact permanently removes a contact based on its ID.
+// Both active and deleted contacts can be purged, provided that they have the expected version.
+// Returns any error encountered during the operation.
+func (s *contactService) PurgeContact(ctx context.Context, id uint, version uint) error {
+	// Retrieve the contact to be purged, including the trash, and check its version
+	contact, err := s.repository.FindByIDWithTrashed(ctx, id)
+	if err != nil {
+		return translateError(err, errContactNotFound)
+	}
+	if err := checkVersion(contact, version); err != nil {
+		return err
+	}
+
+	// Permanently remove the contact, then the files of its attachments
+	if err := s.repository.Purge(ctx, contact); err != nil {
+		return translateError(err, errContactNotFound)
+	}
+	s.attachments.remove(ctx, contact.Attachments)
+	return nil
+}
+
+// patchContactRequest applies a JSON Merge Patch to the ContactRequest representation of a contact.
+// It returns an error if a patched field has the wrong JSON type.
+func patchContactRequest(contact *models.Contact, patch map[string]interface{}) (*requests.ContactRequest, error) {
+	current, err := json.Marshal(requests.ContactRequest{
+		Name:    contact.FullName,
+		Email:   contact.Email,
+		Phone:   contact.Phone,
+		Message: contact.Message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(current, &document); err != nil {
+		return nil, err
+	}
+
+	patched, err := json.Marshal(helpers.MergePatch(document, patch))
+	if err != nil {
+		return nil, err
+	}
+
+	var req requests.ContactRequest
+	if err := json.Unmarshal(patched, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// checkVersion returns an ErrPreconditionFailed error unless the contact has the expected
+// version. A version of 0 matches any version.
+func checkVersion(contact *models.Contact, version uint) error {
+	if version != 0 && contact.Version != version {
+		return errContactVersionMismatch
+	}
+	return nil
 }
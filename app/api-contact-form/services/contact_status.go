@@ -0,0 +1,103 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file implements the contact handling workflow: the state machine of contact statuses,
+// and the ContactService methods that move contacts through it and report their history.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/models"
+	"api-contact-form/requests"
+	"context"
+)
+
+// contactStatusTransitions maps each contact status to the statuses it may move to.
+//
+//	new         → read, in_progress, closed, spam
+//	read        → in_progress, replied, closed, spam
+//	in_progress → replied, closed, spam
+//	replied     → in_progress, closed
+//	closed      → in_progress
+//	spam        → new
+var contactStatusTransitions = map[string][]string{
+	models.ContactStatusNew:        {models.ContactStatusRead, models.ContactStatusInProgress, models.ContactStatusClosed, models.ContactStatusSpam},
+	models.ContactStatusRead:       {models.ContactStatusInProgress, models.ContactStatusReplied, models.ContactStatusClosed, models.ContactStatusSpam},
+	models.ContactStatusInProgress: {models.ContactStatusReplied, models.ContactStatusClosed, models.ContactStatusSpam},
+	models.ContactStatusReplied:    {models.ContactStatusInProgress, models.ContactStatusClosed},
+	models.ContactStatusClosed:     {models.ContactStatusInProgress},
+	models.ContactStatusSpam:       {models.ContactStatusNew},
+}
+
+// AllowedContactStatusTransitions returns the statuses a contact with the given status may move to.
+func AllowedContactStatusTransitions(from string) []string {
+	return contactStatusTransitions[from]
+}
+
+// canTransitionContactStatus reports whether a contact may move from one status to another.
+func canTransitionContactStatus(from, to string) bool {
+	for _, allowed := range contactStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionContact moves an existing contact identified by its ID to another status.
+// It validates the request, retrieves the contact, checks its version and that the transition
+// is allowed, then persists the new status together with a record of the transition.
+// Returns the updated Contact and any error encountered. An illegal transition returns an
+// ErrInvalidStatusTransition error.
+func (s *contactService) TransitionContact(ctx context.Context, id uint, version uint, req *requests.ContactTransitionRequest, actor string) (*models.Contact, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	// Retrieve the existing contact and check its version
+	contact, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	if err := checkVersion(contact, version); err != nil {
+		return nil, err
+	}
+
+	// Check the transition against the workflow
+	from := contact.Status
+	if !canTransitionContactStatus(from, req.Status) {
+		return nil, &Error{
+			Kind:       ErrInvalidStatusTransition,
+			MessageKey: i18n.MsgInvalidStatusTransition,
+			Args:       []interface{}{from, req.Status},
+		}
+	}
+
+	// Persist the new status and record the transition
+	contact.Status = req.Status
+	err = s.repository.UpdateStatus(ctx, contact, &models.ContactStatusTransition{
+		ContactID:  contact.ID,
+		FromStatus: from,
+		ToStatus:   req.Status,
+		Actor:      actor,
+	})
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	return contact, nil
+}
+
+// GetContactTransitions retrieves the status history of an existing contact identified by its ID.
+// Returns the status transitions, oldest first, and any error encountered.
+func (s *contactService) GetContactTransitions(ctx context.Context, id uint) ([]models.ContactStatusTransition, error) {
+	// Make sure the contact exists
+	if _, err := s.repository.FindByID(ctx, id); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	transitions, err := s.repository.FindStatusTransitions(ctx, id)
+	return transitions, translateError(err, errContactNotFound)
+}
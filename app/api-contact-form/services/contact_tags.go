@@ -0,0 +1,69 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file implements the ContactService methods that attach tags to and detach tags from
+// contacts, one at a time or in bulk.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/models"
+	"api-contact-form/requests"
+	"context"
+)
+
+// AddContactTags attaches tags to an existing contact identified by its ID.
+// It validates the request and persists the tags using the repository. Tags that the contact
+// already has are left as they are.
+// Returns the updated Contact with its tags and any error encountered. An unknown tag returns
+// an ErrNotFound error.
+func (s *contactService) AddContactTags(ctx context.Context, id uint, req *requests.ContactTagsRequest) (*models.Contact, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	if err := s.repository.ChangeTags(ctx, []uint{id}, req.TagIDs, nil); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	contact, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	return contact, nil
+}
+
+// RemoveContactTag detaches a tag from an existing contact identified by its ID.
+// Detaching a tag that the contact does not have is not an error.
+// Returns the updated Contact with its tags and any error encountered.
+func (s *contactService) RemoveContactTag(ctx context.Context, id uint, tagID uint) (*models.Contact, error) {
+	if err := s.repository.ChangeTags(ctx, []uint{id}, nil, []uint{tagID}); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	contact, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	return contact, nil
+}
+
+// BulkTagContacts attaches tags to and detaches tags from many existing contacts at once.
+// It validates the request and applies every change in a single transaction, so that either
+// all contacts are changed or none is.
+// Returns the updated Contacts with their tags, ordered by ID, and any error encountered.
+func (s *contactService) BulkTagContacts(ctx context.Context, req *requests.BulkTagRequest) ([]models.Contact, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	if err := s.repository.ChangeTags(ctx, req.ContactIDs, req.Add, req.Remove); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+
+	contacts, err := s.repository.FindByIDs(ctx, req.ContactIDs)
+	return contacts, translateError(err, errContactNotFound)
+}
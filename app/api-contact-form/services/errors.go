@@ -0,0 +1,192 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the typed domain errors returned by the services, so that callers can
+// tell missing resources, invalid input, and conflicting changes apart from unexpected
+// failures without inspecting database-specific errors.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/repositories"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound indicates that the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrValidation indicates that the request data failed validation.
+	ErrValidation = errors.New("validation failed")
+	// ErrConflict indicates that the request conflicts with the current state of a resource.
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed indicates that a resource no longer has the version the client expected.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrInvalidStatusTransition indicates that the workflow does not allow a contact to move
+	// from its current status to the requested one.
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	// ErrIdempotencyKeyMismatch indicates that an idempotency key was reused for a different request.
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key reused with a different request")
+	// ErrIdempotencyKeyInProgress indicates that the request holding an idempotency key has not completed yet.
+	ErrIdempotencyKeyInProgress = errors.New("idempotency key in use by a request in progress")
+	// ErrInvalidCursor indicates that a pagination cursor could not be decoded.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrMailUnavailable indicates that no mail server is configured to send emails.
+	ErrMailUnavailable = errors.New("mail unavailable")
+	// ErrMailDelivery indicates that the mail server did not accept an email.
+	ErrMailDelivery = errors.New("mail delivery failed")
+	// ErrInvalidAttachment indicates that a submitted file breaks the attachment limits.
+	ErrInvalidAttachment = errors.New("invalid attachment")
+	// ErrScanUnavailable indicates that a submitted file could not be scanned for malware.
+	ErrScanUnavailable = errors.New("malware scan unavailable")
+	// ErrAttachmentQuarantined indicates that an attachment contains malware and cannot be downloaded.
+	ErrAttachmentQuarantined = errors.New("attachment quarantined")
+	// ErrUnprocessableEmail indicates that an inbound email cannot be turned into a contact or reply.
+	ErrUnprocessableEmail = errors.New("unprocessable email")
+)
+
+// Error is a domain error with a message that is safe to show to API clients.
+type Error struct {
+	// Kind is the sentinel error that classifies the error, such as ErrNotFound.
+	Kind error
+	// MessageKey is the key of the human-readable description of the error in the i18n catalogs.
+	MessageKey string
+	// Args holds the values formatted into the message, if any.
+	Args []interface{}
+}
+
+// Error returns the human-readable description of the error in the default language.
+func (e *Error) Error() string {
+	return i18n.T(i18n.DefaultLanguage, e.MessageKey, e.Args...)
+}
+
+// Unwrap returns the sentinel error that classifies the error.
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// ValidationError indicates that the request data failed validation.
+// It wraps the underlying validator error and matches ErrValidation.
+type ValidationError struct {
+	// Err is the underlying validation error.
+	Err error
+}
+
+// Error returns the description of the underlying validation error.
+func (e *ValidationError) Error() string {
+	return ErrValidation.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying validation error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// FieldError describes a field whose value breaks a rule that is not expressed in validator
+// tags, such as a rule of a form field.
+type FieldError struct {
+	// Field is the name of the field, such as "company" or "fields[1].name".
+	Field string
+	// Rule is the rule the value breaks, such as "required".
+	Rule string
+	// Param is the parameter of the rule, such as the maximum length. It may be empty.
+	Param string
+	// MessageKey is the key of the description of the error in the i18n catalogs.
+	MessageKey string
+	// Args holds the values formatted into the message, starting with the field name.
+	Args []interface{}
+}
+
+// FieldErrors lists the fields whose values break a rule. It is returned wrapped in a
+// ValidationError.
+type FieldErrors []FieldError
+
+// Error returns the descriptions of the field errors in the default language.
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fe := range e {
+		messages = append(messages, i18n.T(i18n.DefaultLanguage, fe.MessageKey, fe.Args...))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// add appends an error of a field, with the field name as the first argument of the message.
+func (e *FieldErrors) add(field string, rule string, param string, messageKey string, args ...interface{}) {
+	*e = append(*e, FieldError{
+		Field:      field,
+		Rule:       rule,
+		Param:      param,
+		MessageKey: messageKey,
+		Args:       append([]interface{}{field}, args...),
+	})
+}
+
+// err returns the field errors as a ValidationError, or nil if there are none.
+func (e FieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return &ValidationError{Err: e}
+}
+
+var (
+	// errContactNotFound is returned when an active contact does not exist.
+	errContactNotFound = &Error{Kind: ErrNotFound, MessageKey: i18n.MsgContactNotFound}
+	// errTrashedContactNotFound is returned when a contact is not in the trash.
+	errTrashedContactNotFound = &Error{Kind: ErrNotFound, MessageKey: i18n.MsgTrashedNotFound}
+	// errContactVersionMismatch is returned when a contact was changed since the client read it.
+	errContactVersionMismatch = &Error{Kind: ErrPreconditionFailed, MessageKey: i18n.MsgVersionMismatch}
+	// errTagNotFound is returned when a tag does not exist.
+	errTagNotFound = &Error{Kind: ErrNotFound, MessageKey: i18n.MsgTagNotFound}
+	// errNoteNotFound is returned when a note does not exist on a contact.
+	errNoteNotFound = &Error{Kind: ErrNotFound, MessageKey: i18n.MsgNoteNotFound}
+	// errAttachmentNotFound is returned when an attachment does not exist on a contact.
+	errAttachmentNotFound = &Error{Kind: ErrNotFound, MessageKey: i18n.MsgAttachmentNotFound}
+	// errAttachmentQuarantined is returned when an attachment in quarantine is downloaded.
+	errAttachmentQuarantined = &Error{Kind: ErrAttachmentQuarantined, MessageKey: i18n.MsgAttachmentQuarantined}
+	// errMailUnavailable is returned when emails cannot be sent because no mail server is configured.
+	errMailUnavailable = &Error{Kind: ErrMailUnavailable, MessageKey: i18n.MsgMailUnavailable}
+	// errMailDelivery is returned when the mail server did not accept an email.
+	errMailDelivery = &Error{Kind: ErrMailDelivery, MessageKey: i18n.MsgMailDeliveryFailed}
+	// errTagNameTaken is returned when another tag already has the requested name.
+	errTagNameTaken = &Error{Kind: ErrConflict, MessageKey: i18n.MsgTagNameTaken}
+	// errFormNotFound is returned when a form does not exist.
+	errFormNotFound = &Error{Kind: ErrNotFound, MessageKey: i18n.MsgFormNotFound}
+	// errFormSlugTaken is returned when another form already has the requested slug.
+	errFormSlugTaken = &Error{Kind: ErrConflict, MessageKey: i18n.MsgFormSlugTaken}
+	// errFormReadOnly is returned when the slug or the rules of the fields of the default form are changed.
+	errFormReadOnly = &Error{Kind: ErrConflict, MessageKey: i18n.MsgFormReadOnly}
+	// errFormUndeletable is returned when the default form is deleted.
+	errFormUndeletable = &Error{Kind: ErrConflict, MessageKey: i18n.MsgFormUndeletable}
+	// errFormInUse is returned when a form that contacts were submitted through is deleted.
+	errFormInUse = &Error{Kind: ErrConflict, MessageKey: i18n.MsgFormInUse}
+)
+
+// translateError converts repository errors into domain errors.
+// Missing records become notFound, missing tags become errTagNotFound, version mismatches
+// become ErrPreconditionFailed, and duplicate keys and foreign key violations become ErrConflict. Other errors are returned unchanged.
+func translateError(err error, notFound error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return notFound
+	case errors.Is(err, repositories.ErrTagNotFound):
+		return errTagNotFound
+	case errors.Is(err, repositories.ErrVersionMismatch):
+		return errContactVersionMismatch
+	case errors.Is(err, gorm.ErrDuplicatedKey), errors.Is(err, gorm.ErrForeignKeyViolated):
+		return &Error{Kind: ErrConflict, MessageKey: i18n.MsgDataConflict}
+	default:
+		return err
+	}
+}
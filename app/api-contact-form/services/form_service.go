@@ -0,0 +1,371 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the FormService interface and its implementation, which manage the forms
+// that contacts are submitted through and check their field schema.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"api-contact-form/requests"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+var (
+	// formSlugPattern matches the slugs of forms, such as "careers" or "partner-program".
+	formSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// formFieldNamePattern matches the names of the fields of forms, such as "company_size".
+	formFieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// textFieldTypes are the types of form fields whose values are text, which a maximum length
+// and a pattern apply to.
+var textFieldTypes = map[string]bool{
+	models.FormFieldText:     true,
+	models.FormFieldTextarea: true,
+	models.FormFieldEmail:    true,
+	models.FormFieldTel:      true,
+	models.FormFieldURL:      true,
+}
+
+// choiceFieldTypes are the types of form fields whose values are picked from their options.
+var choiceFieldTypes = map[string]bool{
+	models.FormFieldSelect:      true,
+	models.FormFieldMultiselect: true,
+}
+
+// FormService defines the interface for form-related business logic operations.
+type FormService interface {
+	// CreateForm creates a new form based on the provided request data.
+	CreateForm(ctx context.Context, req *requests.FormRequest) (*models.Form, error)
+	// GetForms retrieves every form, ordered by slug.
+	GetForms(ctx context.Context) ([]models.Form, error)
+	// GetForm retrieves a form by its slug.
+	GetForm(ctx context.Context, slug string) (*models.Form, error)
+	// UpdateForm updates an existing form identified by its slug. Only the texts and thank-you
+	// URL of the default form can be updated.
+	UpdateForm(ctx context.Context, slug string, req *requests.FormRequest) (*models.Form, error)
+	// DeleteForm removes a form identified by its slug, provided that no contacts were
+	// submitted through it. The default form cannot be deleted.
+	DeleteForm(ctx context.Context, slug string) error
+	// SubmitForm creates a new contact from the values submitted through a form identified
+	// by its slug, after checking them against the fields of the form.
+	SubmitForm(ctx context.Context, slug string, values map[string]interface{}) (*models.Contact, error)
+}
+
+// formService is the concrete implementation of FormService.
+// It creates the contacts submitted through forms with the ContactService.
+type formService struct {
+	repository repositories.FormRepository
+	contacts   ContactService
+	validate   *validator.Validate
+}
+
+// NewFormService creates a new instance of FormService with the provided FormRepository,
+// the ContactService that creates submitted contacts, and the validator used for request validation.
+func NewFormService(repository repositories.FormRepository, contacts ContactService, validate *validator.Validate) FormService {
+	return &formService{
+		repository: repository,
+		contacts:   contacts,
+		validate:   validate,
+	}
+}
+
+// CreateForm creates a new form based on the provided FormRequest.
+// It validates the request and its field schema, and persists the form using the repository.
+// Returns the created Form and any error encountered. A slug that is already taken returns
+// an ErrConflict error.
+func (s *formService) CreateForm(ctx context.Context, req *requests.FormRequest) (*models.Form, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+	if err := checkFormSchema(req); err != nil {
+		return nil, err
+	}
+
+	form := models.Form{
+		Slug:         req.Slug,
+		Name:         strings.TrimSpace(req.Name),
+		Description:  strings.TrimSpace(req.Description),
+		Fields:       formFieldsFromRequest(req.Fields),
+		ThankYouURL:  strings.TrimSpace(req.ThankYouURL),
+		Translations: formTranslationsFromRequest(req.Translations),
+	}
+
+	err := s.repository.Create(ctx, &form)
+	if err != nil {
+		return nil, translateFormError(err)
+	}
+	return &form, nil
+}
+
+// GetForms retrieves every form from the repository, ordered by slug.
+// Returns a slice of Form models and any error encountered.
+func (s *formService) GetForms(ctx context.Context) ([]models.Form, error) {
+	forms, err := s.repository.FindAll(ctx)
+	return forms, translateFormError(err)
+}
+
+// GetForm retrieves a form by its slug from the repository.
+// Returns the Form and any error encountered.
+func (s *formService) GetForm(ctx context.Context, slug string) (*models.Form, error) {
+	form, err := s.repository.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, translateFormError(err)
+	}
+	return form, nil
+}
+
+// UpdateForm updates the slug, name, description, fields, thank-you URL and translations of an
+// existing form identified by its slug.
+// It validates the request and its field schema, retrieves the form, and persists the changes
+// using the repository. Contacts submitted earlier keep the values of fields that were removed.
+// The default form keeps its slug and the rules of its fields, so that its submissions keep
+// matching /contacts; changing them returns an ErrConflict error.
+// Returns the updated Form and any error encountered.
+func (s *formService) UpdateForm(ctx context.Context, slug string, req *requests.FormRequest) (*models.Form, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+	if err := checkFormSchema(req); err != nil {
+		return nil, err
+	}
+
+	// Retrieve the existing form
+	form, err := s.repository.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, translateFormError(err)
+	}
+
+	// Only the texts of the default form can change
+	fields := formFieldsFromRequest(req.Fields)
+	if form.Slug == models.DefaultFormSlug && (req.Slug != form.Slug || !sameFieldRules(form.Fields, fields)) {
+		return nil, errFormReadOnly
+	}
+
+	// Update the form fields
+	form.Slug = req.Slug
+	form.Name = strings.TrimSpace(req.Name)
+	form.Description = strings.TrimSpace(req.Description)
+	form.Fields = fields
+	form.ThankYouURL = strings.TrimSpace(req.ThankYouURL)
+	form.Translations = formTranslationsFromRequest(req.Translations)
+
+	if err := s.repository.Update(ctx, form); err != nil {
+		return nil, translateFormError(err)
+	}
+	return form, nil
+}
+
+// DeleteForm removes a form identified by its slug. A form that contacts were submitted
+// through cannot be deleted, and returns an ErrConflict error.
+// Returns any error encountered.
+func (s *formService) DeleteForm(ctx context.Context, slug string) error {
+	if slug == models.DefaultFormSlug {
+		return errFormUndeletable
+	}
+
+	// Retrieve the existing form
+	form, err := s.repository.FindBySlug(ctx, slug)
+	if err != nil {
+		return translateFormError(err)
+	}
+
+	return translateFormError(s.repository.Delete(ctx, form))
+}
+
+// translateFormError converts repository errors of form operations into domain errors.
+// A duplicate slug becomes errFormSlugTaken, a form still referenced by contacts becomes
+// errFormInUse, and other errors are converted by translateError.
+func translateFormError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return errFormSlugTaken
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return errFormInUse
+	default:
+		return translateError(err, errFormNotFound)
+	}
+}
+
+// checkFormSchema checks the rules of a form that validator tags cannot express: the format
+// of the slug and field names, unique field names, the options of choice fields, the patterns
+// of text fields, the fields that fill the columns of the contact, the thank-you URL, and the
+// languages of translations. Every form needs a required field named email of type email, so
+// that the contact can be replied to.
+// It returns a ValidationError listing the broken rules, or nil.
+func checkFormSchema(req *requests.FormRequest) error {
+	var fieldErrs FieldErrors
+	if !formSlugPattern.MatchString(req.Slug) {
+		fieldErrs.add("slug", "slug", "", i18n.MsgFormSlugInvalid)
+	}
+	if url := strings.TrimSpace(req.ThankYouURL); url != "" && !validThankYouURL(url) {
+		fieldErrs.add("thank_you_url", "thank_you_url", "", i18n.MsgFormThankYouURLInvalid)
+	}
+	checkTranslationLanguages(&fieldErrs, "translations", sortedKeys(req.Translations))
+
+	names := make(map[string]bool, len(req.Fields))
+	hasEmail := false
+	for i, field := range req.Fields {
+		path := fmt.Sprintf("fields[%d]", i)
+
+		switch {
+		case !formFieldNamePattern.MatchString(field.Name):
+			fieldErrs.add(path+".name", "field_name", "", i18n.MsgFormFieldNameInvalid)
+		case names[field.Name]:
+			fieldErrs.add(path+".name", "unique", "", i18n.MsgFormFieldDuplicate)
+		}
+		names[field.Name] = true
+
+		// The fields named after the columns of the contact must hold text
+		if field.Name == contactEmailField {
+			hasEmail = field.Type == models.FormFieldEmail && field.Required
+		} else if _, column := contactColumnLimits[field.Name]; column && !textFieldTypes[field.Type] {
+			fieldErrs.add(path+".type", "text", "", i18n.MsgFormFieldNotText)
+		}
+
+		if choiceFieldTypes[field.Type] && len(field.Options) == 0 {
+			fieldErrs.add(path+".options", "required", "", i18n.MsgFormOptionsRequired)
+		}
+		if field.Pattern != "" && textFieldTypes[field.Type] {
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				fieldErrs.add(path+".pattern", "regexp", "", i18n.MsgFormPatternInvalid)
+			}
+		}
+		checkTranslationLanguages(&fieldErrs, path+".translations", sortedKeys(field.Translations))
+	}
+	if !hasEmail {
+		fieldErrs.add("fields", "email_field", "", i18n.MsgFormEmailField)
+	}
+
+	return fieldErrs.err()
+}
+
+// checkTranslationLanguages adds a field error for each language of the translations at path
+// that has no catalog of messages.
+func checkTranslationLanguages(fieldErrs *FieldErrors, path string, languages []string) {
+	for _, lang := range languages {
+		if !i18n.IsSupported(lang) {
+			fieldErrs.add(path+"."+lang, "language", "", i18n.MsgFormLanguageInvalid)
+		}
+	}
+}
+
+// validThankYouURL reports whether a thank-you URL is an absolute http or https URL, or a
+// path of the site starting with a single "/".
+func validThankYouURL(thankYouURL string) bool {
+	if strings.HasPrefix(thankYouURL, "/") {
+		return !strings.HasPrefix(thankYouURL, "//")
+	}
+	parsed, err := url.Parse(thankYouURL)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// formFieldsFromRequest maps the fields of a FormRequest to FormField models. Options are
+// kept for choice fields only, and the maximum length and pattern for text fields only.
+// Trimmed texts are kept, and empty translations are dropped.
+func formFieldsFromRequest(fields []requests.FormFieldRequest) []models.FormField {
+	formFields := make([]models.FormField, 0, len(fields))
+	for _, field := range fields {
+		formField := models.FormField{
+			Name:        field.Name,
+			Label:       strings.TrimSpace(field.Label),
+			Placeholder: strings.TrimSpace(field.Placeholder),
+			Type:        field.Type,
+			Required:    field.Required,
+		}
+		if choiceFieldTypes[field.Type] {
+			formField.Options = field.Options
+		}
+		if textFieldTypes[field.Type] {
+			formField.MaxLength = field.MaxLength
+			formField.Pattern = field.Pattern
+		}
+
+		for lang, translation := range field.Translations {
+			fieldTranslation := models.FormFieldTranslation{
+				Label:       strings.TrimSpace(translation.Label),
+				Placeholder: strings.TrimSpace(translation.Placeholder),
+			}
+			for option, text := range translation.Options {
+				if text = strings.TrimSpace(text); text != "" && containsString(formField.Options, option) {
+					if fieldTranslation.Options == nil {
+						fieldTranslation.Options = make(map[string]string)
+					}
+					fieldTranslation.Options[option] = text
+				}
+			}
+			if fieldTranslation.Label == "" && fieldTranslation.Placeholder == "" && fieldTranslation.Options == nil {
+				continue
+			}
+			if formField.Translations == nil {
+				formField.Translations = make(map[string]models.FormFieldTranslation)
+			}
+			formField.Translations[lang] = fieldTranslation
+		}
+
+		formFields = append(formFields, formField)
+	}
+	return formFields
+}
+
+// formTranslationsFromRequest maps the translations of a FormRequest to FormTranslation models,
+// with trimmed texts. Empty translations are dropped, and nil is returned when none are left.
+func formTranslationsFromRequest(translations map[string]requests.FormTranslationRequest) map[string]models.FormTranslation {
+	var formTranslations map[string]models.FormTranslation
+	for lang, translation := range translations {
+		formTranslation := models.FormTranslation{
+			Name:        strings.TrimSpace(translation.Name),
+			Description: strings.TrimSpace(translation.Description),
+		}
+		if formTranslation.Name == "" && formTranslation.Description == "" {
+			continue
+		}
+		if formTranslations == nil {
+			formTranslations = make(map[string]models.FormTranslation)
+		}
+		formTranslations[lang] = formTranslation
+	}
+	return formTranslations
+}
+
+// sameFieldRules reports whether two lists of fields have the same names, types and rules, in
+// the same order, whatever their texts.
+func sameFieldRules(a []models.FormField, b []models.FormField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Type != b[i].Type || a[i].Required != b[i].Required ||
+			a[i].MaxLength != b[i].MaxLength || a[i].Pattern != b[i].Pattern ||
+			strings.Join(a[i].Options, "\n") != strings.Join(b[i].Options, "\n") {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns the keys of a map in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,263 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file implements the submissions of forms: the submitted values are checked against
+// the fields of the form, and become a new contact. The values of the fields named name,
+// email, phone and message fill the matching columns of the contact, and the values of the
+// other fields are kept with the contact.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/models"
+	"api-contact-form/requests"
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// contactNameField is the name of the form field that fills the full name of the contact.
+	contactNameField = "name"
+	// contactEmailField is the name of the form field that fills the email address of the contact.
+	contactEmailField = "email"
+	// contactPhoneField is the name of the form field that fills the phone number of the contact.
+	contactPhoneField = "phone"
+	// contactMessageField is the name of the form field that fills the message of the contact.
+	contactMessageField = "message"
+)
+
+const (
+	// defaultTextMaxLength is the maximum length of the values of text fields without one.
+	defaultTextMaxLength = 255
+	// defaultTextareaMaxLength is the maximum length of the values of textarea fields without one.
+	defaultTextareaMaxLength = 10000
+)
+
+// contactColumnLimits maps the names of the form fields that fill a column of the contact to
+// the maximum length of the column. Zero means the column has no maximum length.
+var contactColumnLimits = map[string]int{
+	contactNameField:    100,
+	contactEmailField:   100,
+	contactPhoneField:   20,
+	contactMessageField: 0,
+}
+
+// SubmitForm creates a new contact from the values submitted through a form identified by its slug.
+// It checks the values against the fields of the form, then creates the contact with the
+// ContactService. Submissions of the default form create the contact exactly as /contacts does.
+// Returns the created Contact and any error encountered. Values that break the rules of their
+// fields, and values of unknown fields, return a ValidationError listing them.
+func (s *formService) SubmitForm(ctx context.Context, slug string, values map[string]interface{}) (*models.Contact, error) {
+	// Retrieve the form
+	form, err := s.repository.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, translateFormError(err)
+	}
+
+	// Check the values against the fields of the form
+	submitted, err := s.checkSubmission(form, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the values to the columns of the contact
+	text := func(name string) string {
+		value, _ := submitted[name].(string)
+		delete(submitted, name)
+		return value
+	}
+	if form.Slug == models.DefaultFormSlug {
+		return s.contacts.CreateContact(ctx, &requests.ContactRequest{
+			Name:    text(contactNameField),
+			Email:   text(contactEmailField),
+			Phone:   text(contactPhoneField),
+			Message: text(contactMessageField),
+		})
+	}
+	req := requests.FormContactRequest{
+		FormID:  form.ID,
+		Name:    text(contactNameField),
+		Email:   text(contactEmailField),
+		Phone:   text(contactPhoneField),
+		Message: text(contactMessageField),
+	}
+	if len(submitted) > 0 {
+		req.FieldValues = submitted
+	}
+	return s.contacts.CreateFormContact(ctx, &req)
+}
+
+// checkSubmission checks the submitted values against the fields of a form.
+// It returns the values to keep by field name, without the fields left empty, or a
+// ValidationError listing the broken rules and the values of unknown fields.
+func (s *formService) checkSubmission(form *models.Form, values map[string]interface{}) (map[string]interface{}, error) {
+	var fieldErrs FieldErrors
+	submitted := make(map[string]interface{}, len(form.Fields))
+
+	known := make(map[string]bool, len(form.Fields))
+	for _, field := range form.Fields {
+		known[field.Name] = true
+		if value := s.fieldValue(field, values[field.Name], &fieldErrs); value != nil {
+			submitted[field.Name] = value
+		}
+	}
+
+	// Report the values of unknown fields, in a stable order
+	unknown := make([]string, 0)
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		fieldErrs.add(name, "unknown", "", i18n.MsgFieldUnknown)
+	}
+
+	if err := fieldErrs.err(); err != nil {
+		return nil, err
+	}
+	return submitted, nil
+}
+
+// fieldValue checks a submitted value against the rules of its field. It returns the value to
+// keep, with text trimmed, or nil if the field was left empty or the value breaks a rule.
+// Broken rules are added to fieldErrs.
+func (s *formService) fieldValue(field models.FormField, raw interface{}, fieldErrs *FieldErrors) interface{} {
+	name := field.Name
+	switch field.Type {
+	case models.FormFieldNumber:
+		if raw == nil {
+			if field.Required {
+				fieldErrs.add(name, "required", "", i18n.MsgFieldRequired)
+			}
+			return nil
+		}
+		number, ok := raw.(float64)
+		if !ok {
+			fieldErrs.add(name, "type", "number", i18n.MsgFieldType, "number")
+			return nil
+		}
+		return number
+
+	case models.FormFieldCheckbox:
+		checked, ok := raw.(bool)
+		if raw != nil && !ok {
+			fieldErrs.add(name, "type", "boolean", i18n.MsgFieldType, "boolean")
+			return nil
+		}
+		if field.Required && !checked {
+			fieldErrs.add(name, "required", "", i18n.MsgFieldRequired)
+			return nil
+		}
+		if raw == nil {
+			return nil
+		}
+		return checked
+
+	case models.FormFieldMultiselect:
+		items, ok := raw.([]interface{})
+		if raw != nil && !ok {
+			fieldErrs.add(name, "type", "array", i18n.MsgFieldType, "array")
+			return nil
+		}
+		selected := make([]string, 0, len(items))
+		seen := make(map[string]bool, len(items))
+		for _, item := range items {
+			option, ok := item.(string)
+			if !ok || !containsString(field.Options, option) {
+				fieldErrs.add(name, "oneof", strings.Join(field.Options, " "), i18n.MsgFieldInvalidOption, strings.Join(field.Options, ", "))
+				return nil
+			}
+			if !seen[option] {
+				seen[option] = true
+				selected = append(selected, option)
+			}
+		}
+		if len(selected) == 0 {
+			if field.Required {
+				fieldErrs.add(name, "required", "", i18n.MsgFieldRequired)
+			}
+			return nil
+		}
+		return selected
+	}
+
+	// The other types hold text
+	text, ok := raw.(string)
+	if raw != nil && !ok {
+		fieldErrs.add(name, "type", "string", i18n.MsgFieldType, "string")
+		return nil
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		if field.Required {
+			fieldErrs.add(name, "required", "", i18n.MsgFieldRequired)
+		}
+		return nil
+	}
+
+	if field.Type == models.FormFieldSelect {
+		if !containsString(field.Options, text) {
+			fieldErrs.add(name, "oneof", strings.Join(field.Options, " "), i18n.MsgFieldInvalidOption, strings.Join(field.Options, ", "))
+			return nil
+		}
+		return text
+	}
+
+	if maxLength := FormFieldMaxLength(field); utf8.RuneCountInString(text) > maxLength {
+		fieldErrs.add(name, "max", strconv.Itoa(maxLength), i18n.MsgFieldTooLong, maxLength)
+		return nil
+	}
+	switch {
+	case field.Type == models.FormFieldEmail && s.validate.Var(text, "email") != nil:
+		fieldErrs.add(name, "email", "", i18n.MsgFieldInvalidEmail)
+		return nil
+	case field.Type == models.FormFieldURL && s.validate.Var(text, "url") != nil:
+		fieldErrs.add(name, "url", "", i18n.MsgFieldInvalidURL)
+		return nil
+	case field.Pattern != "" && !matchesInFull(field.Pattern, text):
+		fieldErrs.add(name, "pattern", field.Pattern, i18n.MsgFieldPatternMismatch)
+		return nil
+	}
+	return text
+}
+
+// FormFieldMaxLength returns the maximum length of the text values of a form field: its own
+// maximum length, or the default of its type, capped by the column of the contact the field fills.
+func FormFieldMaxLength(field models.FormField) int {
+	maxLength := field.MaxLength
+	if maxLength == 0 {
+		maxLength = defaultTextMaxLength
+		if field.Type == models.FormFieldTextarea {
+			maxLength = defaultTextareaMaxLength
+		}
+	}
+	if limit := contactColumnLimits[field.Name]; limit > 0 && limit < maxLength {
+		maxLength = limit
+	}
+	return maxLength
+}
+
+// matchesInFull reports whether the whole text matches the regular expression pattern.
+// A pattern that does not compile matches nothing.
+func matchesInFull(pattern string, text string) bool {
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	return err == nil && re.MatchString(text)
+}
+
+// containsString reports whether the values include the value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
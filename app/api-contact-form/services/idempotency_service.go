@@ -0,0 +1,158 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the IdempotencyService interface and its implementation, which make
+// requests sent with an Idempotency-Key header safe to retry: the first request with a key
+// is processed and its response stored, and retries with the same key and request replay
+// the stored response instead of being processed again.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StoredResponse is the response of a request sent with an idempotency key.
+type StoredResponse struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Headers holds the response headers to replay, such as Content-Type.
+	Headers map[string]string
+	// Body is the body of the response.
+	Body []byte
+}
+
+// IdempotencyService defines the methods for handling requests sent with an idempotency key.
+type IdempotencyService interface {
+	// Begin reserves an idempotency key for a request identified by its hash.
+	// If the key belongs to a completed request with the same hash, the stored response of
+	// that request is returned and must be replayed. Otherwise, the returned response is nil
+	// and the request must be processed, then passed to Complete or Release.
+	Begin(ctx context.Context, key, requestHash string) (*StoredResponse, error)
+	// Complete stores the response of the request that reserved an idempotency key.
+	Complete(ctx context.Context, key string, response *StoredResponse) error
+	// Release removes the reservation of an idempotency key whose request failed,
+	// so that the request can be retried with the same key.
+	Release(ctx context.Context, key string) error
+	// PurgeExpired removes the expired idempotency keys and returns how many were removed.
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// idempotencyService is the concrete implementation of IdempotencyService.
+type idempotencyService struct {
+	repository repositories.IdempotencyRepository
+	ttl        time.Duration
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService with the provided
+// IdempotencyRepository. Idempotency keys expire, and can be reused, once ttl has elapsed.
+func NewIdempotencyService(repository repositories.IdempotencyRepository, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{
+		repository: repository,
+		ttl:        ttl,
+	}
+}
+
+// Begin reserves an idempotency key for a request identified by its hash.
+// It returns ErrIdempotencyKeyMismatch if the key was used for a different request, and
+// ErrIdempotencyKeyInProgress if the request that reserved the key has not completed yet.
+// Expired keys are removed and reserved again; if another request does so first, the key is
+// reported in progress.
+func (s *idempotencyService) Begin(ctx context.Context, key, requestHash string) (*StoredResponse, error) {
+	// Try to reserve the key; a second attempt is made if an expired key had to be removed
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		err := s.repository.Create(ctx, &models.IdempotencyKey{
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   now.Add(s.ttl),
+		})
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, err
+		}
+
+		// Inspect the request that already holds the key
+		existing, err := s.repository.FindByKey(ctx, key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The key was released in the meantime
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case existing.ExpiresAt.Before(now):
+			// Remove the expired key, unless another request removed and reserved it first
+			removed, err := s.repository.DeleteIfExpired(ctx, key, now)
+			if err != nil {
+				return nil, err
+			}
+			if !removed {
+				return nil, ErrIdempotencyKeyInProgress
+			}
+		case existing.RequestHash != requestHash:
+			return nil, ErrIdempotencyKeyMismatch
+		case !existing.Completed():
+			return nil, ErrIdempotencyKeyInProgress
+		default:
+			return storedResponseFromModel(existing)
+		}
+	}
+
+	return nil, ErrIdempotencyKeyInProgress
+}
+
+// Complete stores the response of the request that reserved an idempotency key.
+// Returns any error encountered.
+func (s *idempotencyService) Complete(ctx context.Context, key string, response *StoredResponse) error {
+	headers, err := json.Marshal(response.Headers)
+	if err != nil {
+		return err
+	}
+
+	statusCode := response.StatusCode
+	return s.repository.Complete(ctx, &models.IdempotencyKey{
+		Key:             key,
+		StatusCode:      &statusCode,
+		ResponseHeaders: string(headers),
+		ResponseBody:    string(response.Body),
+	})
+}
+
+// Release removes the reservation of an idempotency key.
+// Returns any error encountered.
+func (s *idempotencyService) Release(ctx context.Context, key string) error {
+	return s.repository.Delete(ctx, key)
+}
+
+// PurgeExpired removes the idempotency keys that have expired.
+// Returns the number of removed keys and any error encountered.
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repository.DeleteExpired(ctx, time.Now())
+}
+
+// storedResponseFromModel converts a completed IdempotencyKey model into a StoredResponse.
+func storedResponseFromModel(record *models.IdempotencyKey) (*StoredResponse, error) {
+	response := &StoredResponse{
+		StatusCode: *record.StatusCode,
+		Body:       []byte(record.ResponseBody),
+	}
+	if record.ResponseHeaders != "" {
+		if err := json.Unmarshal([]byte(record.ResponseHeaders), &response.Headers); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,267 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the InboundEmailService interface and its implementation, which turn the
+// emails received by the SMTP listener into contacts, or append them to the thread of an
+// existing contact when they reply to it. Files attached to the emails are kept as attachments
+// of the contact when they meet the attachment limits, and left out otherwise.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/inbound"
+	"api-contact-form/mailer"
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"api-contact-form/requests"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// contactThreadTagPrefix prefixes the contact ID in the tag of plus-addressed replies,
+	// such as "support+contact-42@example.com".
+	contactThreadTagPrefix = "contact-"
+	// maxMessageIDLength is the maximum length of a Message-ID kept in a thread.
+	maxMessageIDLength = 255
+	// maxEmailContactNameLength is the maximum length, in characters, of the name of a contact
+	// created from an email.
+	maxEmailContactNameLength = 100
+)
+
+// InboundEmailService defines the interface for processing the emails sent to the contact mailbox.
+type InboundEmailService interface {
+	// ReceiveEmail adds an inbound email to the thread of the contact it replies to, or creates
+	// a new contact from it. The reply is matched by the In-Reply-To and References headers, or
+	// by the contact tag of a plus-addressed recipient when the sender is the submitter of the
+	// contact. Emails that were already received are ignored.
+	// It fails with ErrUnprocessableEmail or ErrValidation if no contact can be created from the email.
+	ReceiveEmail(ctx context.Context, email *inbound.Email) (*models.ContactThreadMessage, error)
+}
+
+// inboundEmailService is the concrete implementation of InboundEmailService.
+type inboundEmailService struct {
+	contacts    ContactService
+	threads     repositories.ContactThreadRepository
+	attachments *Attachments
+	mailbox     string
+}
+
+// NewInboundEmailService creates a new instance of InboundEmailService with the provided
+// ContactService, ContactThreadRepository, Attachments, and the address of the contact mailbox.
+func NewInboundEmailService(contacts ContactService, threads repositories.ContactThreadRepository, attachments *Attachments, mailbox string) InboundEmailService {
+	return &inboundEmailService{
+		contacts:    contacts,
+		threads:     threads,
+		attachments: attachments,
+		mailbox:     mailbox,
+	}
+}
+
+// ReceiveEmail adds an inbound email to the thread of the contact it replies to, or creates
+// a new contact from it.
+// Returns the thread message recording the email and any error encountered.
+func (s *inboundEmailService) ReceiveEmail(ctx context.Context, email *inbound.Email) (*models.ContactThreadMessage, error) {
+	if email.From == nil {
+		return nil, fmt.Errorf("%w: missing sender", ErrUnprocessableEmail)
+	}
+	messageID := email.MessageID
+	if messageID == "" || len(messageID) > maxMessageIDLength {
+		messageID = mailer.NewMessageID(messageIDDomain(&mail.Address{Address: s.mailbox}))
+	}
+
+	// Ignore emails that were already received, such as redeliveries.
+	if existing, err := s.threads.FindByMessageIDs(ctx, []string{messageID}); err != nil {
+		return nil, translateError(err, errContactNotFound)
+	} else if len(existing) > 0 {
+		return &existing[0], nil
+	}
+
+	message := models.ContactThreadMessage{
+		Direction:   models.ThreadDirectionInbound,
+		MessageID:   messageID,
+		InReplyTo:   email.InReplyTo,
+		References:  strings.Join(email.References, " "),
+		FromAddress: email.From.String(),
+		ToAddress:   s.recipient(email),
+		Subject:     truncate(email.Subject, maxSubjectLength),
+		Body:        email.Text,
+		Status:      models.ThreadStatusReceived,
+		SentAt:      &email.Date,
+	}
+	if len(message.InReplyTo) > maxMessageIDLength {
+		message.InReplyTo = ""
+	}
+
+	// Find the contact the email replies to and attach the files to it, or create one with them
+	// and the message in a single transaction, so that a retried delivery finds the message.
+	uploads := s.attachments.acceptable(emailUploads(email))
+	contact, err := s.repliedContact(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if contact == nil {
+		_, err = s.contacts.CreateEmailContact(ctx, emailContactRequest(email), &message, uploads...)
+		if errors.Is(err, ErrConflict) {
+			return s.receivedConcurrently(ctx, messageID, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &message, nil
+	}
+	if len(uploads) > 0 {
+		if _, err := s.attachments.attach(ctx, contact.ID, uploads); err != nil {
+			return nil, err
+		}
+	}
+
+	message.ContactID = contact.ID
+	if err := s.threads.Create(ctx, &message); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return s.receivedConcurrently(ctx, messageID, err)
+		}
+		return nil, translateError(err, errContactNotFound)
+	}
+	return &message, nil
+}
+
+// receivedConcurrently returns the thread message recording an email with the given Message-ID
+// that was received concurrently, after storing the same email failed with err.
+// It returns err, translated, if there is no such message.
+func (s *inboundEmailService) receivedConcurrently(ctx context.Context, messageID string, err error) (*models.ContactThreadMessage, error) {
+	existing, findErr := s.threads.FindByMessageIDs(ctx, []string{messageID})
+	if findErr == nil && len(existing) > 0 {
+		return &existing[0], nil
+	}
+	return nil, translateError(err, errContactNotFound)
+}
+
+// repliedContact returns the contact an email replies to, or nil if it is a new conversation.
+// Contacts that were deleted since start a new conversation.
+func (s *inboundEmailService) repliedContact(ctx context.Context, email *inbound.Email) (*models.Contact, error) {
+	// Match the emails the email replies to, most recent first.
+	ids := append([]string{}, email.References...)
+	if email.InReplyTo != "" {
+		ids = append(ids, email.InReplyTo)
+	}
+	replied, err := s.threads.FindByMessageIDs(ctx, ids)
+	if err != nil {
+		return nil, translateError(err, errContactNotFound)
+	}
+	for _, message := range replied {
+		contact, err := s.contacts.GetContactByID(ctx, message.ContactID)
+		if err == nil {
+			return contact, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	// Match the contact tag of a plus-addressed recipient. Since the tag can be guessed, it
+	// only matches emails from the submitter of the contact.
+	for _, recipient := range email.Recipients {
+		contactID, ok := s.contactIDFromRecipient(recipient)
+		if !ok {
+			continue
+		}
+		contact, err := s.contacts.GetContactByID(ctx, contactID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(contact.Email, email.From.Address) {
+			return contact, nil
+		}
+	}
+	return nil, nil
+}
+
+// contactIDFromRecipient returns the contact ID in the tag of a plus-addressed recipient of
+// the mailbox, if any.
+func (s *inboundEmailService) contactIDFromRecipient(recipient string) (uint, bool) {
+	base, tag := mailer.SplitPlusAddress(recipient)
+	if !strings.EqualFold(base, s.mailbox) || !strings.HasPrefix(tag, contactThreadTagPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(tag, contactThreadTagPrefix), 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// recipient returns the recipient of an email recorded in the thread: the mailbox address
+// it was delivered to, or the mailbox itself.
+func (s *inboundEmailService) recipient(email *inbound.Email) string {
+	for _, recipient := range email.Recipients {
+		if base, _ := mailer.SplitPlusAddress(recipient); strings.EqualFold(base, s.mailbox) {
+			return recipient
+		}
+	}
+	return s.mailbox
+}
+
+// contactThreadAddress returns the plus-addressed variant of the mailbox that routes replies
+// to the thread of a contact, such as "support+contact-42@example.com".
+func contactThreadAddress(mailbox string, contactID uint) string {
+	return mailer.PlusAddress(mailbox, contactThreadTagPrefix+strconv.FormatUint(uint64(contactID), 10))
+}
+
+// emailContactRequest maps an inbound email to the request creating its contact. The name of
+// the sender defaults to the local part of their address, and emails without text use their
+// subject as the message.
+func emailContactRequest(email *inbound.Email) *requests.EmailContactRequest {
+	name := strings.TrimSpace(email.From.Name)
+	if name == "" {
+		name = email.From.Address
+		if at := strings.LastIndex(name, "@"); at > 0 {
+			name = name[:at]
+		}
+	}
+	message := email.Text
+	if message == "" {
+		message = email.Subject
+	}
+	return &requests.EmailContactRequest{
+		Name:    truncate(name, maxEmailContactNameLength),
+		Email:   email.From.Address,
+		Message: message,
+	}
+}
+
+// emailUploads returns the files attached to an inbound email as uploads.
+func emailUploads(email *inbound.Email) []Upload {
+	uploads := make([]Upload, 0, len(email.Attachments))
+	for _, attachment := range email.Attachments {
+		data := attachment.Data
+		uploads = append(uploads, Upload{
+			Filename: attachment.Filename,
+			Size:     int64(len(data)),
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		})
+	}
+	return uploads
+}
+
+// truncate returns the text cut to at most max characters.
+func truncate(text string, max int) string {
+	if runes := []rune(text); len(runes) > max {
+		return string(runes[:max])
+	}
+	return text
+}
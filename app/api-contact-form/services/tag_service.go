@@ -0,0 +1,173 @@
+// Package services provides business logic implementations for the API Contact Form application.
+//
+// This file defines the TagService interface and its implementation, which manage the tags
+// used to categorize contacts.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package services
+
+import (
+	"api-contact-form/i18n"
+	"api-contact-form/models"
+	"api-contact-form/repositories"
+	"api-contact-form/requests"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// TagService defines the interface for tag-related business logic operations.
+type TagService interface {
+	// CreateTag creates a new tag based on the provided request data.
+	CreateTag(ctx context.Context, req *requests.TagRequest) (*models.Tag, error)
+	// GetTags retrieves every tag, ordered by name.
+	GetTags(ctx context.Context) ([]models.Tag, error)
+	// GetTag retrieves a tag by its ID.
+	GetTag(ctx context.Context, id uint) (*models.Tag, error)
+	// UpdateTag updates the name and color of an existing tag identified by its ID.
+	UpdateTag(ctx context.Context, id uint, req *requests.TagRequest) (*models.Tag, error)
+	// DeleteTag removes a tag identified by its ID, detaching it from every contact.
+	DeleteTag(ctx context.Context, id uint) error
+}
+
+// tagService is the concrete implementation of TagService.
+type tagService struct {
+	repository repositories.TagRepository
+	validate   *validator.Validate
+}
+
+// NewTagService creates a new instance of TagService with the provided TagRepository
+// and the validator used for request validation.
+func NewTagService(repository repositories.TagRepository, validate *validator.Validate) TagService {
+	return &tagService{
+		repository: repository,
+		validate:   validate,
+	}
+}
+
+// CreateTag creates a new tag based on the provided TagRequest.
+// It validates the request, normalizes the name, and persists the tag using the repository.
+// Returns the created Tag and any error encountered. A name that is already taken returns
+// an ErrConflict error.
+func (s *tagService) CreateTag(ctx context.Context, req *requests.TagRequest) (*models.Tag, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	name, err := tagNameFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := models.Tag{
+		Name:  name,
+		Color: req.Color,
+	}
+
+	if err := s.repository.Create(ctx, &tag); err != nil {
+		return nil, translateTagError(err)
+	}
+	return &tag, nil
+}
+
+// GetTags retrieves every tag from the repository, ordered by name.
+// Returns a slice of Tag models and any error encountered.
+func (s *tagService) GetTags(ctx context.Context) ([]models.Tag, error) {
+	tags, err := s.repository.FindAll(ctx)
+	return tags, translateTagError(err)
+}
+
+// GetTag retrieves a tag by its ID from the repository.
+// Returns the Tag and any error encountered.
+func (s *tagService) GetTag(ctx context.Context, id uint) (*models.Tag, error) {
+	tag, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateTagError(err)
+	}
+	return tag, nil
+}
+
+// UpdateTag updates the name and color of an existing tag identified by its ID.
+// It validates the request, retrieves the tag, and persists the changes using the repository.
+// Returns the updated Tag and any error encountered.
+func (s *tagService) UpdateTag(ctx context.Context, id uint, req *requests.TagRequest) (*models.Tag, error) {
+	// Validate input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	name, err := tagNameFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the existing tag
+	tag, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateTagError(err)
+	}
+
+	// Update the tag fields
+	tag.Name = name
+	tag.Color = req.Color
+
+	if err := s.repository.Update(ctx, tag); err != nil {
+		return nil, translateTagError(err)
+	}
+	return tag, nil
+}
+
+// DeleteTag removes a tag identified by its ID. The tag is detached from every contact.
+// Returns any error encountered.
+func (s *tagService) DeleteTag(ctx context.Context, id uint) error {
+	// Retrieve the existing tag
+	tag, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return translateTagError(err)
+	}
+
+	return translateTagError(s.repository.Delete(ctx, tag))
+}
+
+// translateTagError converts repository errors of tag operations into domain errors.
+// A duplicate name becomes errTagNameTaken; other errors are converted by translateError.
+func translateTagError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return errTagNameTaken
+	}
+	return translateError(err, errTagNotFound)
+}
+
+// tagNameFromRequest returns the normalized name of a tag request. It returns a ValidationError
+// if the name is blank, since a blank name cannot be used to attach or find the tag.
+func tagNameFromRequest(req *requests.TagRequest) (string, error) {
+	name := normalizeTagName(req.Name)
+	if name == "" {
+		var fieldErrs FieldErrors
+		fieldErrs.add("name", "required", "", i18n.MsgFieldRequired)
+		return "", fieldErrs.err()
+	}
+	return name, nil
+}
+
+// normalizeTagName returns the stored form of a tag name: trimmed and in lower case, so that
+// tag names match regardless of how they are typed.
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// normalizeTagNames returns the stored form of the tag names, as normalizeTagName does.
+func normalizeTagNames(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = normalizeTagName(name); name != "" {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,272 @@
+// Package storage stores the files of the API Contact Form application, such as the
+// attachments of contacts.
+//
+// This file implements FileStore on S3-compatible object storage, such as Amazon S3 or MinIO.
+// Every key is the name of an object in the bucket, after an optional prefix that lets several
+// environments share one bucket.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+const (
+	// SSENone stores objects without requesting server-side encryption.
+	SSENone = ""
+	// SSES3 requests server-side encryption with keys managed by the storage service.
+	SSES3 = "AES256"
+	// SSEKMS requests server-side encryption with a key managed by a key management service.
+	SSEKMS = "aws:kms"
+)
+
+// unknownSizePartSize is the part size of uploads whose length is unknown. It caps the memory
+// buffered for each upload, while allowing objects of up to 160 GiB in 10000 parts.
+const unknownSizePartSize = 16 << 20
+
+// S3Config configures a FileStore on S3-compatible object storage.
+type S3Config struct {
+	// Endpoint is the URL of the storage service, such as "https://s3.amazonaws.com" or
+	// "http://minio:9000".
+	Endpoint string
+	// PublicEndpoint is the URL signed download URLs point to, when clients reach the storage
+	// service at another address than the API does. It defaults to Endpoint.
+	PublicEndpoint string
+	// Region is the region of the bucket, such as "us-east-1".
+	Region string
+	// Bucket is the name of the bucket the objects are kept in.
+	Bucket string
+	// AccessKey and SecretKey are the static credentials of the API. When empty, credentials
+	// are read from the AWS_* environment variables or the instance role instead.
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket in the path of URLs rather than in the host name, as
+	// most S3-compatible services other than Amazon S3 expect.
+	PathStyle bool
+	// Prefix is prepended to every key, such as "production/", so that several environments
+	// can share a bucket.
+	Prefix string
+	// SSE is the server-side encryption requested for new objects: SSENone, SSES3, or SSEKMS.
+	SSE string
+	// SSEKMSKeyID is the key used with SSEKMS. When empty, the default key of the bucket is used.
+	SSEKMSKeyID string
+	// PresignExpiry is how long signed download URLs stay valid. When zero, files are not
+	// downloaded from signed URLs.
+	PresignExpiry time.Duration
+	// CreateBucket creates the bucket when it does not exist, which is handy for local MinIO.
+	CreateBucket bool
+}
+
+// s3Store is the S3-compatible object storage implementation of FileStore.
+type s3Store struct {
+	client    *minio.Client
+	presigner *minio.Client
+	bucket    string
+	prefix    string
+	sse       encrypt.ServerSide
+	expiry    time.Duration
+}
+
+// NewS3Store creates a FileStore that keeps files as objects of an S3-compatible bucket.
+// It checks that the bucket exists, creating it if allowed, and returns an error if it does not.
+func NewS3Store(ctx context.Context, config S3Config) (FileStore, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	// Build the server-side encryption of new objects.
+	var sse encrypt.ServerSide
+	switch config.SSE {
+	case SSENone:
+	case SSES3:
+		sse = encrypt.NewSSE()
+	case SSEKMS:
+		var err error
+		if sse, err = encrypt.NewSSEKMS(config.SSEKMSKeyID, nil); err != nil {
+			return nil, fmt.Errorf("invalid KMS key: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported server-side encryption %q", config.SSE)
+	}
+
+	// Connect to the storage service, and to its public address to sign download URLs.
+	client, err := newS3Client(config, config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	presigner := client
+	if config.PublicEndpoint != "" {
+		if presigner, err = newS3Client(config, config.PublicEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	// Make sure the bucket exists.
+	exists, err := client.BucketExists(ctx, config.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket %s: %w", config.Bucket, err)
+	}
+	if !exists {
+		if !config.CreateBucket {
+			return nil, fmt.Errorf("bucket %s does not exist", config.Bucket)
+		}
+		if err := client.MakeBucket(ctx, config.Bucket, minio.MakeBucketOptions{Region: config.Region}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket %s: %w", config.Bucket, err)
+		}
+	}
+
+	prefix := strings.Trim(config.Prefix, "/")
+	if prefix != "" {
+		if !validKey(prefix) {
+			return nil, fmt.Errorf("invalid key prefix %q", config.Prefix)
+		}
+		prefix += "/"
+	}
+
+	return &s3Store{
+		client:    client,
+		presigner: presigner,
+		bucket:    config.Bucket,
+		prefix:    prefix,
+		sse:       sse,
+		expiry:    config.PresignExpiry,
+	}, nil
+}
+
+// Put uploads the content read from r as the object of the key.
+func (s *s3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	object, err := s.objectName(key)
+	if err != nil {
+		return err
+	}
+	options := minio.PutObjectOptions{
+		ContentType:          contentType,
+		ServerSideEncryption: s.sse,
+	}
+	if size < 0 {
+		options.PartSize = unknownSizePartSize
+	}
+	_, err = s.client.PutObject(ctx, s.bucket, object, r, size, options)
+	return err
+}
+
+// Open downloads the object of the key.
+func (s *s3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.objectName(key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := s.client.GetObject(ctx, s.bucket, object, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, notFoundError(err)
+	}
+
+	// The object is only requested when first read, so look it up to report missing objects now.
+	if _, err := content.Stat(); err != nil {
+		content.Close()
+		return nil, notFoundError(err)
+	}
+	return content, nil
+}
+
+// Delete removes the object of the key.
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	object, err := s.objectName(key)
+	if err != nil {
+		return err
+	}
+	return notFoundIgnored(s.client.RemoveObject(ctx, s.bucket, object, minio.RemoveObjectOptions{}))
+}
+
+// SignedURL returns a URL from which the object of the key can be downloaded without credentials
+// until the presign expiry elapses. The response of the URL makes browsers save the file under
+// the filename. It returns an empty URL when the presign expiry is zero.
+func (s *s3Store) SignedURL(ctx context.Context, key string, filename string, contentType string) (string, error) {
+	if s.expiry <= 0 {
+		return "", nil
+	}
+	object, err := s.objectName(key)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response-content-type", contentType)
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": filename}); disposition != "" {
+		params.Set("response-content-disposition", disposition)
+	}
+	signed, err := s.presigner.PresignedGetObject(ctx, s.bucket, object, s.expiry, params)
+	if err != nil {
+		return "", err
+	}
+	return signed.String(), nil
+}
+
+// objectName returns the name of the object of a key.
+func (s *s3Store) objectName(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	return s.prefix + key, nil
+}
+
+// newS3Client returns a client of the storage service at the endpoint URL.
+func newS3Client(config S3Config, endpoint string) (*minio.Client, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q", endpoint)
+	}
+
+	creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
+	if config.AccessKey == "" {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+		})
+	}
+	lookup := minio.BucketLookupAuto
+	if config.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	// The region is set, so that signing URLs needs no request to the storage service.
+	return minio.New(parsed.Host, &minio.Options{
+		Creds:        creds,
+		Secure:       parsed.Scheme == "https",
+		Region:       config.Region,
+		BucketLookup: lookup,
+	})
+}
+
+// notFoundError converts the error of a missing object to ErrNotFound.
+func notFoundError(err error) error {
+	if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+// notFoundIgnored returns nil for the error of a missing object, and the error otherwise.
+func notFoundIgnored(err error) error {
+	if errors.Is(notFoundError(err), ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+// Package storage stores the files of the API Contact Form application, such as the
+// attachments of contacts.
+//
+// It defines the FileStore interface, which keeps files under keys such as
+// "attachments/2024/05/3f9a...", so that the rest of the application does not depend on
+// where the files are kept, and the URLSigner interface of stores that hand out download URLs.
+//
+// Author: Tri Wicaksono
+// Website: https://triwicaksono.com
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound indicates that no file is stored under the requested key.
+var ErrNotFound = errors.New("file not found")
+
+// ErrInvalidKey indicates that a key is empty or tries to escape the store.
+var ErrInvalidKey = errors.New("invalid file key")
+
+// FileStore keeps files under keys made of slash-separated segments.
+type FileStore interface {
+	// Put stores the content read from r under the key, replacing any file stored under it.
+	// size is the length of the content, or -1 if unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns the content of the file stored under the key. The caller must close it.
+	// It returns ErrNotFound if no file is stored under the key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file stored under the key. Deleting a missing file is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// URLSigner is implemented by file stores that can hand out temporary URLs from which clients
+// download files directly, rather than through the API.
+type URLSigner interface {
+	// SignedURL returns a temporary URL from which the file stored under the key is downloaded
+	// as an attachment named filename, with the content type. It returns an empty URL when
+	// signing URLs is disabled.
+	SignedURL(ctx context.Context, key string, filename string, contentType string) (string, error)
+}
+
+// validKey reports whether a key is made of non-empty segments other than "." and "..".
+func validKey(key string) bool {
+	if key == "" || strings.ContainsAny(key, "\\\x00") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -136,7 +136,7 @@ public function update(Request $request, $id)
         ]);
 
         // Update contact with validated data
-        $contact = $this->contactService->updateContact($id, $validatedData);
+        $contact = $this->contactService->updateContact($id, $request->input('version'), $validatedData);
 
         // Check if update was successful
         if ($contact) {
@@ -151,13 +151,14 @@ public function update(Request $request, $id)
     /**
      * Remove the specified contact from the API.
      *
+     * @param  \Illuminate\Http\Request  $request
      * @param  int  $id
      * @return \Illuminate\Http\RedirectResponse
      */
-    public function destroy($id)
+    public function destroy(Request $request, $id)
     {
         // Attempt to delete contact by ID
-        $success = $this->contactService->deleteContact($id);
+        $success = $this->contactService->deleteContact($id, $request->input('version'));
 
         // Check if deletion was successful
         if ($success) {
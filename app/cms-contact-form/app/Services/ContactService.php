@@ -99,13 +99,18 @@ public function createContact(array $data)
     /**
      * Update an existing contact via the API.
      *
+     * The version is sent as the If-Match header, so the update fails if the
+     * contact was changed by someone else in the meantime.
+     *
      * @param  int    $id
+     * @param  int    $version
      * @param  array  $data
      * @return array|null
      */
-    public function updateContact($id, array $data)
+    public function updateContact($id, $version, array $data)
     {
-        $response = Http::put("{$this->apiUrl}/{$id}", $data);
+        $response = Http::withHeaders($this->ifMatch($version))
+            ->put("{$this->apiUrl}/{$id}", $data);
 
         if ($response->successful() && $response['code'] === 'SUCCESS' && is_array($response['data'])) {
             return $response['data'];
@@ -125,12 +130,17 @@ public function updateContact($id, array $data)
     /**
      * Delete a contact by ID via the API.
      *
+     * The version is sent as the If-Match header, so the deletion fails if the
+     * contact was changed by someone else in the meantime.
+     *
      * @param  int  $id
+     * @param  int  $version
      * @return string|null
      */
-    public function deleteContact($id)
+    public function deleteContact($id, $version)
     {
-        $response = Http::delete("{$this->apiUrl}/{$id}");
+        $response = Http::withHeaders($this->ifMatch($version))
+            ->delete("{$this->apiUrl}/{$id}");
 
         if ($response->successful() && $response['code'] === 'SUCCESS') {
             return $response['code'];
@@ -146,4 +156,15 @@ public function deleteContact($id)
 
         return null;
     }
+
+    /**
+     * Build the If-Match header expecting the given contact version.
+     *
+     * @param  int  $version
+     * @return array
+     */
+    protected function ifMatch($version)
+    {
+        return ['If-Match' => '"' . (int) $version . '"'];
+    }
 }
\ No newline at end of file
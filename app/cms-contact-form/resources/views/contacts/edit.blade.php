@@ -13,6 +13,7 @@ class="max-w-lg mx-auto bg-white p-6 rounded shadow-md"
     >
         @csrf
         @method('PUT')
+        <input type="hidden" name="version" value="{{ old('version', $contact['version']) }}">
         <div class="mb-4">
             <label class="block mb-2 font-semibold">Name</label>
             <input type="text" name="name" value="{{ old('name', $contact['name']) }}" class="w-full border border-gray-300 rounded px-3 py-2 focus:outline-none focus:ring-2 focus:ring-blue-500" required>
@@ -46,6 +46,7 @@
                             <form action="{{ route('contacts.destroy', $contact['id']) }}" method="POST" onsubmit="return confirm('Are you sure to delete this contact?');">
                                 @csrf
                                 @method('DELETE')
+                                <input type="hidden" name="version" value="{{ $contact['version'] }}">
                                 <button type="submit" class="bg-red-500 text-white px-3 py-1 rounded hover:bg-red-600">Delete</button>
                             </form>
                         </td>
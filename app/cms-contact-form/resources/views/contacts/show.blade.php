@@ -17,6 +17,7 @@
             <form action="{{ route('contacts.destroy', $contact['id']) }}" method="POST" onsubmit="return confirm('Are you sure you want to delete this contact?');">
                 @csrf
                 @method('DELETE')
+                <input type="hidden" name="version" value="{{ $contact['version'] }}">
                 <button type="submit" class="bg-red-500 text-white px-4 py-2 rounded hover:bg-red-600">Delete</button>
             </form>
         </div>